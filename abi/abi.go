@@ -1,4 +1,4 @@
-//go:build go1.24 && !go1.27
+//go:build go1.24 && !go1.27 && !gointernals_safe
 
 package abi
 
@@ -6,11 +6,6 @@ import (
 	"unsafe"
 )
 
-type Eface struct {
-	Type *Type
-	Data unsafe.Pointer
-}
-
 // from runtime/runtime2.go
 type Iface struct {
 	Tab  *ITab
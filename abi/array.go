@@ -0,0 +1,53 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import "unsafe"
+
+// ArrayType represents a fixed array type (from internal/abi/type.go).
+type ArrayType struct {
+	Type
+	Elem  *Type // array element type
+	Slice *Type // slice type
+	Len   uintptr
+}
+
+// ArrayType returns t cast to a *ArrayType, or nil if t is not an array type.
+func (t *Type) ArrayType() *ArrayType {
+	if t.Kind() != Array {
+		return nil
+	}
+	return (*ArrayType)(unsafe.Pointer(t))
+}
+
+// Len returns the length of t if t is an array type, otherwise 0.
+func (t *Type) Len() int {
+	if t.Kind() == Array {
+		return int((*ArrayType)(unsafe.Pointer(t)).Len)
+	}
+	return 0
+}
+
+// Elem returns the element type for t if t is an array, channel, map, pointer, or slice, otherwise nil.
+func (t *Type) Elem() *Type {
+	switch t.Kind() {
+	case Array:
+		return (*ArrayType)(unsafe.Pointer(t)).Elem
+	case Chan:
+		return (*ChanType)(unsafe.Pointer(t)).Elem
+	case Map:
+		type mapType struct {
+			Type
+			Key  *Type
+			Elem *Type
+		}
+		return (*mapType)(unsafe.Pointer(t)).Elem
+	case Pointer, Slice:
+		type elemType struct {
+			Type
+			Elem *Type
+		}
+		return (*elemType)(unsafe.Pointer(t)).Elem
+	}
+	return nil
+}
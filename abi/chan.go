@@ -0,0 +1,51 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import "unsafe"
+
+// ChanDir represents a channel type's direction.
+type ChanDir int
+
+const (
+	RecvDir    ChanDir = 1 << iota         // <-chan
+	SendDir                                // chan<-
+	BothDir            = RecvDir | SendDir // chan
+	InvalidDir ChanDir = 0
+)
+
+// String returns the channel direction as it appears in a type literal.
+func (d ChanDir) String() string {
+	switch d {
+	case RecvDir:
+		return "<-chan"
+	case SendDir:
+		return "chan<-"
+	case BothDir:
+		return "chan"
+	}
+	return "invalid"
+}
+
+// ChanType represents a channel type (from internal/abi/type.go).
+type ChanType struct {
+	Type
+	Elem *Type
+	Dir  ChanDir
+}
+
+// ChanType returns t cast to a *ChanType, or nil if t is not a channel type.
+func (t *Type) ChanType() *ChanType {
+	if t.Kind() != Chan {
+		return nil
+	}
+	return (*ChanType)(unsafe.Pointer(t))
+}
+
+// ChanDir returns the direction of t if t is a channel type, otherwise InvalidDir (0).
+func (t *Type) ChanDir() ChanDir {
+	if t.Kind() == Chan {
+		return (*ChanType)(unsafe.Pointer(t)).Dir
+	}
+	return InvalidDir
+}
@@ -0,0 +1,20 @@
+package abi
+
+import "unsafe"
+
+// Eface is the layout of an empty interface value (any).
+type Eface struct {
+	Type *Type
+	Data unsafe.Pointer
+}
+
+// TypeOf returns the abi.Type of some value.
+func TypeOf(a any) *Type {
+	eface := *(*Eface)(unsafe.Pointer(&a))
+	// Types are either static (for compiler-created types) or
+	// heap-allocated but always reachable (for reflection-created
+	// types, held in the central map). So there is no need to
+	// escape types. noescape here help avoid unnecessary escape
+	// of v.
+	return (*Type)(NoEscape(unsafe.Pointer(eface.Type)))
+}
@@ -0,0 +1,113 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// FuncType represents a function type (from internal/abi/type.go).
+//
+// A *Type for each in and out parameter is stored in an array that
+// directly follows the funcType (and possibly its uncommonType). So
+// a function type with one method, one input, and one output is:
+//
+//	struct {
+//		funcType
+//		uncommonType
+//		[2]*rtype    // [0] is in, [1] is out
+//	}
+type FuncType struct {
+	Type
+	InCount  uint16
+	OutCount uint16 // top bit is set if last input parameter is ...
+}
+
+// FuncType returns t cast to a *FuncType, or nil if t is not a func type.
+func (t *Type) FuncType() *FuncType {
+	if t.Kind() != Func {
+		return nil
+	}
+	return (*FuncType)(unsafe.Pointer(t))
+}
+
+// NumIn returns the number of input parameters of t.
+func (t *FuncType) NumIn() int {
+	return int(t.InCount)
+}
+
+// NumOut returns the number of output parameters of t.
+func (t *FuncType) NumOut() int {
+	return int(t.OutCount & (1<<15 - 1))
+}
+
+// In returns the type of the i'th input parameter of t.
+func (t *FuncType) In(i int) *Type {
+	return t.InSlice()[i]
+}
+
+// Out returns the type of the i'th output parameter of t.
+func (t *FuncType) Out(i int) *Type {
+	return t.OutSlice()[i]
+}
+
+// IsVariadic reports whether the last input parameter of t is a "..." parameter.
+func (t *FuncType) IsVariadic() bool {
+	return t.OutCount&(1<<15) != 0
+}
+
+// params returns the in and out parameter types of t, in that order.
+func (t *FuncType) params() []*Type {
+	n := int(t.InCount) + t.NumOut()
+	if n == 0 {
+		return nil
+	}
+	uadd := unsafe.Sizeof(*t)
+	if t.TFlag&TFlagUncommon != 0 {
+		uadd += unsafe.Sizeof(UncommonType{})
+	}
+	return unsafe.Slice((**Type)(unsafe.Add(unsafe.Pointer(t), uadd)), n)
+}
+
+// InSlice returns the input parameter types of t.
+//
+// The returned slice aliases the type descriptor and must not be modified.
+func (t *FuncType) InSlice() []*Type {
+	if t.InCount == 0 {
+		return nil
+	}
+	return t.params()[:t.InCount:t.InCount]
+}
+
+// OutSlice returns the output parameter types of t.
+//
+// The returned slice aliases the type descriptor and must not be modified.
+func (t *FuncType) OutSlice() []*Type {
+	if t.NumOut() == 0 {
+		return nil
+	}
+	return t.params()[t.InCount:]
+}
+
+// Ins returns an iterator over the index and type of each input parameter of t.
+func (t *FuncType) Ins() iter.Seq2[int, *Type] {
+	return func(yield func(int, *Type) bool) {
+		for i, typ := range t.InSlice() {
+			if !yield(i, typ) {
+				return
+			}
+		}
+	}
+}
+
+// Outs returns an iterator over the index and type of each output parameter of t.
+func (t *FuncType) Outs() iter.Seq2[int, *Type] {
+	return func(yield func(int, *Type) bool) {
+		for i, typ := range t.OutSlice() {
+			if !yield(i, typ) {
+				return
+			}
+		}
+	}
+}
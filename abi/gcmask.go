@@ -0,0 +1,65 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// PtrSize is the size of a pointer in bytes.
+const PtrSize = unsafe.Sizeof(uintptr(0))
+
+// GCMask returns the GC pointer bitmask of t, one bit per pointer-sized word
+// of the first PtrBytes bytes of t, least significant bit first.
+//
+// If TFlagGCMaskOnDemand is set, the bitmask is built on first use and cached.
+// The returned slice may alias runtime memory and must not be modified.
+func (t *Type) GCMask() []byte {
+	if t.PtrBytes == 0 {
+		return nil
+	}
+	nwords := t.PtrBytes / PtrSize
+	return unsafe.Slice(gcMask(t), (nwords+7)/8)
+}
+
+// IsPointerWord reports whether the pointer-sized word at byte offset off in t
+// holds a pointer.
+func (t *Type) IsPointerWord(off uintptr) bool {
+	if off >= t.PtrBytes || off%PtrSize != 0 {
+		return false
+	}
+	i := off / PtrSize
+	return t.GCMask()[i/8]>>(i%8)&1 != 0
+}
+
+// PointerOffsets returns an iterator over the byte offsets of the words
+// in t that hold pointers, in increasing order.
+func (t *Type) PointerOffsets() iter.Seq[uintptr] {
+	return func(yield func(uintptr) bool) {
+		mask := t.GCMask()
+		for i, b := range mask {
+			for b != 0 {
+				bit := uintptr(0)
+				for b&(1<<bit) == 0 {
+					bit++
+				}
+				if !yield((uintptr(i)*8 + bit) * PtrSize) {
+					return
+				}
+				b &^= 1 << bit
+			}
+		}
+	}
+}
+
+// NumPointers returns the number of words in t that hold pointers.
+func (t *Type) NumPointers() int {
+	n := 0
+	for _, b := range t.GCMask() {
+		for ; b != 0; b &= b - 1 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,60 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe && !gointernals_linkname
+
+package abi
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// gcMasks caches the bitmasks built for TFlagGCMaskOnDemand types.
+// The runtime keeps its own cache, but runtime.getGCMask is not on the
+// linker's linkname allowlist.
+var gcMasks sync.Map // map[*Type]*byte
+
+// gcMask returns the GC pointer bitmask of t, building and caching it
+// if TFlagGCMaskOnDemand is set.
+func gcMask(t *Type) *byte {
+	if t.TFlag&TFlagGCMaskOnDemand == 0 {
+		return t.GCData
+	}
+	if p, ok := gcMasks.Load(t); ok {
+		return p.(*byte)
+	}
+	mask := make([]byte, (t.PtrBytes/PtrSize+7)/8)
+	buildGCMask(t, mask, 0)
+	p, _ := gcMasks.LoadOrStore(t, &mask[0])
+	return p.(*byte)
+}
+
+// buildGCMask writes the bitmask of t into mask starting at bit word,
+// following runtime.buildGCMask.
+func buildGCMask(t *Type, mask []byte, word uintptr) {
+	if t.PtrBytes == 0 {
+		return
+	}
+	if t.TFlag&TFlagGCMaskOnDemand == 0 {
+		n := t.PtrBytes / PtrSize
+		src := unsafe.Slice(t.GCData, (n+7)/8)
+		for i := range n {
+			if src[i/8]>>(i%8)&1 != 0 {
+				j := word + i
+				mask[j/8] |= 1 << (j % 8)
+			}
+		}
+		return
+	}
+	switch t.Kind() {
+	case Array:
+		a := t.ArrayType()
+		for i := range a.Len {
+			buildGCMask(a.Elem, mask, word+i*(a.Elem.Size/PtrSize))
+		}
+	case Struct:
+		for _, f := range t.StructType().Fields {
+			buildGCMask(f.Typ, mask, word+f.Offset/PtrSize)
+		}
+	default:
+		panic("gointernals/abi.buildGCMask: unexpected kind " + t.Kind().String())
+	}
+}
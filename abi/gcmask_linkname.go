@@ -0,0 +1,15 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe && gointernals_linkname
+
+package abi
+
+import _ "unsafe"
+
+//go:linkname getGCMask runtime.getGCMask
+//go:noescape
+func getGCMask(t *Type) *byte
+
+// gcMask returns the GC pointer bitmask of t, letting the runtime build
+// and cache it if TFlagGCMaskOnDemand is set.
+func gcMask(t *Type) *byte {
+	return getGCMask(t)
+}
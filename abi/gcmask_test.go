@@ -0,0 +1,100 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"slices"
+	"testing"
+	"unsafe"
+)
+
+type testGCStruct struct {
+	A int
+	P *int
+	S string
+	B [3]int
+	M map[string]int
+	E any
+	C int
+}
+
+func TestGCMask(t *testing.T) {
+	t.Run("no pointers", func(t *testing.T) {
+		typ := TypeOf([4]int{})
+		if typ.GCMask() != nil {
+			t.Error("Expected nil mask for pointer-free type")
+		}
+		if typ.NumPointers() != 0 {
+			t.Error("Expected 0 pointers")
+		}
+		for range typ.PointerOffsets() {
+			t.Error("Expected no pointer offsets")
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		var v testGCStruct
+		typ := TypeOf(v)
+		want := []uintptr{
+			unsafe.Offsetof(v.P),
+			unsafe.Offsetof(v.S),
+			unsafe.Offsetof(v.M),
+			unsafe.Offsetof(v.E) + PtrSize, // the type word of an interface is not a heap pointer
+		}
+		got := slices.Collect(typ.PointerOffsets())
+		if !slices.Equal(got, want) {
+			t.Errorf("PointerOffsets() = %v, want %v", got, want)
+		}
+		if typ.NumPointers() != len(want) {
+			t.Errorf("NumPointers() = %d, want %d", typ.NumPointers(), len(want))
+		}
+		if !typ.IsPointerWord(unsafe.Offsetof(v.P)) || typ.IsPointerWord(unsafe.Offsetof(v.A)) {
+			t.Error("Unexpected IsPointerWord result")
+		}
+		if typ.IsPointerWord(unsafe.Offsetof(v.C)) {
+			t.Error("Expected word past PtrBytes to be a non-pointer")
+		}
+	})
+
+	t.Run("on demand", func(t *testing.T) {
+		// Types larger than MaxPtrmaskBytes*8 words have their masks built at runtime.
+		var v [1 << 15]*int
+		typ := TypeOf(&v).Elem()
+		if typ.TFlag&TFlagGCMaskOnDemand == 0 {
+			t.Skip("mask is not built on demand for this type")
+		}
+		if n := typ.NumPointers(); n != len(v) {
+			t.Errorf("NumPointers() = %d, want %d", n, len(v))
+		}
+		i := uintptr(0)
+		for off := range typ.PointerOffsets() {
+			if off != i*PtrSize {
+				t.Fatalf("offset %d = %d, want %d", i, off, i*PtrSize)
+			}
+			i++
+		}
+	})
+
+	t.Run("on demand struct", func(t *testing.T) {
+		type big struct {
+			A [1 << 14]int
+			P *int
+			B [1 << 12]testGCStruct
+		}
+		var v big
+		typ := TypeOf(&v).Elem()
+		if typ.TFlag&TFlagGCMaskOnDemand == 0 {
+			t.Skip("mask is not built on demand for this type")
+		}
+		elem := TypeOf(testGCStruct{})
+		want := []uintptr{unsafe.Offsetof(v.P)}
+		for i := range uintptr(1 << 12) {
+			for off := range elem.PointerOffsets() {
+				want = append(want, unsafe.Offsetof(v.B)+i*elem.Size+off)
+			}
+		}
+		if got := slices.Collect(typ.PointerOffsets()); !slices.Equal(got, want) {
+			t.Errorf("PointerOffsets() has %d offsets, want %d", len(got), len(want))
+		}
+	})
+}
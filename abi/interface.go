@@ -0,0 +1,45 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// Imethod represents a method on an interface type (from internal/abi/type.go).
+type Imethod struct {
+	Name NameOff // name of method
+	Typ  TypeOff // .(*FuncType) underneath
+}
+
+// InterfaceType represents an interface type (from internal/abi/type.go).
+type InterfaceType struct {
+	Type
+	PkgPath Name      // import path
+	Methods []Imethod // sorted by hash
+}
+
+// InterfaceType returns t cast to a *InterfaceType, or nil if t is not an interface type.
+func (t *Type) InterfaceType() *InterfaceType {
+	if t.Kind() != Interface {
+		return nil
+	}
+	return (*InterfaceType)(unsafe.Pointer(t))
+}
+
+// NumMethod returns the number of interface methods in the type's method set.
+func (t *InterfaceType) NumMethod() int {
+	return len(t.Methods)
+}
+
+// AllMethods returns an iterator over the index and method of each method in t.
+func (t *InterfaceType) AllMethods() iter.Seq2[int, *Imethod] {
+	return func(yield func(int, *Imethod) bool) {
+		for i := range t.Methods {
+			if !yield(i, &t.Methods[i]) {
+				return
+			}
+		}
+	}
+}
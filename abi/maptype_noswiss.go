@@ -0,0 +1,19 @@
+//go:build go1.24 && !go1.26 && !goexperiment.swissmap && !gointernals_safe
+
+package abi
+
+import "unsafe"
+
+// mapType mirrors the layout of the runtime's legacy (noswissmap) map type
+// descriptor so that the UncommonType following it can be located.
+type mapType struct {
+	Type
+	Key        *Type
+	Elem       *Type
+	Bucket     *Type
+	Hasher     func(unsafe.Pointer, uintptr) uintptr
+	KeySize    uint8
+	ValueSize  uint8
+	BucketSize uint16
+	Flags      uint32
+}
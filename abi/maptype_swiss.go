@@ -0,0 +1,19 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe && (go1.26 || goexperiment.swissmap)
+
+package abi
+
+import "unsafe"
+
+// mapType mirrors the layout of the runtime's map type descriptor so that
+// the UncommonType following it can be located.
+type mapType struct {
+	Type
+	Key       *Type
+	Elem      *Type
+	Group     *Type
+	Hasher    func(unsafe.Pointer, uintptr) uintptr
+	GroupSize uintptr
+	SlotSize  uintptr
+	ElemOff   uintptr
+	Flags     uint32
+}
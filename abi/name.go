@@ -0,0 +1,117 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import "unsafe"
+
+// Name is an encoded type Name with optional extra data (from internal/abi/type.go).
+//
+// The first byte is a bit field containing:
+//
+//	1<<0 the name is exported
+//	1<<1 tag data follows the name
+//	1<<2 pkgPath nameOff follows the name and tag
+//	1<<3 the name is of an embedded (a.k.a. anonymous) field
+//
+// Following that, there is a varint-encoded length of the name,
+// followed by the name itself.
+//
+// If tag data is present, it also has a varint-encoded length
+// followed by the tag itself.
+//
+// If the import path follows, then 4 bytes at the end of
+// the data form a nameOff. The import path is only set for concrete
+// methods that are defined in a different package than their type.
+//
+// If a name starts with "*", then the exported bit represents
+// whether the pointed to type is exported.
+type Name struct {
+	Bytes *byte
+}
+
+// Data returns a pointer to the byte at offset off in n.
+func (n Name) Data(off int) *byte {
+	return (*byte)(unsafe.Add(unsafe.Pointer(n.Bytes), off))
+}
+
+// IsExported reports whether n is exported.
+func (n Name) IsExported() bool {
+	return (*n.Bytes)&(1<<0) != 0
+}
+
+// HasTag reports whether there is tag data following this name.
+func (n Name) HasTag() bool {
+	return (*n.Bytes)&(1<<1) != 0
+}
+
+// HasPkgPath reports whether a pkgPath nameOff follows the name and tag.
+func (n Name) HasPkgPath() bool {
+	return (*n.Bytes)&(1<<2) != 0
+}
+
+// IsEmbedded reports whether n is embedded (an anonymous field).
+func (n Name) IsEmbedded() bool {
+	return (*n.Bytes)&(1<<3) != 0
+}
+
+// ReadVarint parses a varint as encoded by encoding/binary.
+// It returns the number of encoded bytes and the encoded value.
+func (n Name) ReadVarint(off int) (int, int) {
+	v := 0
+	for i := 0; ; i++ {
+		x := *n.Data(off + i)
+		v += int(x&0x7f) << (7 * i)
+		if x&0x80 == 0 {
+			return i + 1, v
+		}
+	}
+}
+
+// IsBlank reports whether n is "_".
+func (n Name) IsBlank() bool {
+	if n.Bytes == nil {
+		return false
+	}
+	_, l := n.ReadVarint(1)
+	return l == 1 && *n.Data(2) == '_'
+}
+
+// Name returns the name of n, or empty if it does not actually have a name.
+func (n Name) Name() string {
+	if n.Bytes == nil {
+		return ""
+	}
+	i, l := n.ReadVarint(1)
+	return unsafe.String(n.Data(1+i), l)
+}
+
+// Tag returns the tag string for n, or empty if there is none.
+func (n Name) Tag() string {
+	if !n.HasTag() {
+		return ""
+	}
+	i, l := n.ReadVarint(1)
+	i2, l2 := n.ReadVarint(1 + i + l)
+	return unsafe.String(n.Data(1+i+l+i2), l2)
+}
+
+// PkgPath returns the import path stored in n, or empty if there is none.
+//
+// The import path is only set for unexported names that are defined in a
+// different package than their type.
+func (n Name) PkgPath() string {
+	if n.Bytes == nil || !n.HasPkgPath() {
+		return ""
+	}
+	i, l := n.ReadVarint(1)
+	off := 1 + i + l
+	if n.HasTag() {
+		i2, l2 := n.ReadVarint(off)
+		off += i2 + l2
+	}
+	var nameOff int32
+	// Note that this field may not be aligned in memory,
+	// so we cannot use a direct int32 assignment here.
+	copy((*[4]byte)(unsafe.Pointer(&nameOff))[:], (*[4]byte)(unsafe.Pointer(n.Data(off)))[:])
+	return Name{Bytes: (*byte)(resolveNameOff(unsafe.Pointer(n.Bytes), nameOff))}.Name()
+}
@@ -0,0 +1,96 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import "unsafe"
+
+// Functions below pushed from runtime.
+
+//go:linkname resolveNameOff reflect.resolveNameOff
+//go:noescape
+func resolveNameOff(ptrInModule unsafe.Pointer, off int32) unsafe.Pointer
+
+//go:linkname resolveTypeOff reflect.resolveTypeOff
+//go:noescape
+func resolveTypeOff(rtype unsafe.Pointer, off int32) unsafe.Pointer
+
+//go:linkname resolveTextOff reflect.resolveTextOff
+//go:noescape
+func resolveTextOff(rtype unsafe.Pointer, off int32) unsafe.Pointer
+
+// NameOff resolves off, relative to the module containing t, to a Name.
+//
+// It works for both compiler-emitted and reflect-created types.
+func (t *Type) NameOff(off NameOff) Name {
+	return Name{Bytes: (*byte)(resolveNameOff(unsafe.Pointer(t), int32(off)))}
+}
+
+// TypeOff resolves off, relative to the module containing t, to a *Type.
+//
+// It works for both compiler-emitted and reflect-created types.
+func (t *Type) TypeOff(off TypeOff) *Type {
+	return (*Type)(resolveTypeOff(unsafe.Pointer(t), int32(off)))
+}
+
+// TextOff resolves off, relative to the module containing t, to a code pointer.
+//
+// Offsets of methods eliminated by the linker resolve to the runtime's
+// unreachableMethod stub.
+func (t *Type) TextOff(off TextOff) unsafe.Pointer {
+	return resolveTextOff(unsafe.Pointer(t), int32(off))
+}
+
+// String returns the string form of t, as printed by reflect.Type.String.
+func (t *Type) String() string {
+	s := t.NameOff(t.Str).Name()
+	if t.TFlag&TFlagExtraStar != 0 {
+		return s[1:]
+	}
+	return s
+}
+
+// Name returns the type's name within its package for a defined type.
+// For other (non-defined) types it returns the empty string.
+func (t *Type) Name() string {
+	if !t.HasName() {
+		return ""
+	}
+	s := t.String()
+	i := len(s) - 1
+	sqBrackets := 0
+	for i >= 0 && (s[i] != '.' || sqBrackets != 0) {
+		switch s[i] {
+		case ']':
+			sqBrackets++
+		case '[':
+			sqBrackets--
+		}
+		i--
+	}
+	return s[i+1:]
+}
+
+// PkgPath returns a defined type's package path, that is, the import path
+// that uniquely identifies the package.
+// If the type was predeclared (string, error) or not defined (*T, struct{},
+// []int, or A where A is an alias for a non-defined type), the package path
+// will be the empty string.
+func (t *Type) PkgPath() string {
+	if !t.HasName() {
+		return ""
+	}
+	ut := t.Uncommon()
+	if ut == nil {
+		return ""
+	}
+	return t.NameOff(ut.PkgPath).Name()
+}
+
+// PtrTo returns the type *T for t, or nil if the binary does not
+// contain it (e.g. for reflect-created types or when *T is never used).
+func (t *Type) PtrTo() *Type {
+	if t.PtrToThis == 0 {
+		return nil
+	}
+	return t.TypeOff(t.PtrToThis)
+}
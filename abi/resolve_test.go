@@ -0,0 +1,93 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+type testNamed int
+
+type testGeneric[T any] struct {
+	V T
+}
+
+func TestTypeString(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     *Type
+		str     string
+		tname   string
+		pkgPath string
+	}{
+		{"int", TypeOf(0), "int", "int", ""},
+		{"string", TypeOf(""), "string", "string", ""},
+		{"pointer", TypeOf(new(int)), "*int", "", ""},
+		{"slice", TypeOf([]string(nil)), "[]string", "", ""},
+		{"map", TypeOf(map[string]int(nil)), "map[string]int", "", ""},
+		{"named", TypeOf(testNamed(0)), "abi.testNamed", "testNamed", "github.com/yusing/gointernals/abi"},
+		{"named pointer", TypeOf(new(testNamed)), "*abi.testNamed", "", ""},
+		{"struct", TypeOf(testStruct{}), "abi.testStruct", "testStruct", "github.com/yusing/gointernals/abi"},
+		{"generic", TypeOf(testGeneric[map[string]int]{}), "abi.testGeneric[map[string]int]", "testGeneric[map[string]int]", "github.com/yusing/gointernals/abi"},
+		{"interface", TypeOf((*io.Reader)(nil)).Elem(), "io.Reader", "Reader", "io"},
+		{"error", TypeOf((*error)(nil)).Elem(), "error", "error", ""},
+		{"unsafe.Pointer", TypeOf(unsafe.Pointer(nil)), "unsafe.Pointer", "Pointer", "unsafe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.typ.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.typ.Name(); got != tt.tname {
+				t.Errorf("Name() = %q, want %q", got, tt.tname)
+			}
+			if got := tt.typ.PkgPath(); got != tt.pkgPath {
+				t.Errorf("PkgPath() = %q, want %q", got, tt.pkgPath)
+			}
+		})
+	}
+}
+
+func TestTypeStringReflectCreated(t *testing.T) {
+	rt := reflect.StructOf([]reflect.StructField{
+		{Name: "A", Type: reflect.TypeFor[int]()},
+		{Name: "B", Type: reflect.TypeFor[string](), Tag: `json:"b"`},
+	})
+	typ := (*Type)(unsafe.Pointer((*Eface)(unsafe.Pointer(&rt)).Data))
+	if got, want := typ.String(), rt.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := typ.Name(); got != "" {
+		t.Errorf("Name() = %q, want empty", got)
+	}
+
+	st := typ.StructType()
+	if st == nil {
+		t.Fatal("Expected non-nil StructType")
+	}
+	if got := st.Field(1).Name.Tag(); got != `json:"b"` {
+		t.Errorf("Tag() = %q, want %q", got, `json:"b"`)
+	}
+
+	sliceOf := reflect.SliceOf(rt)
+	sliceTyp := (*Type)(unsafe.Pointer((*Eface)(unsafe.Pointer(&sliceOf)).Data))
+	if got, want := sliceTyp.String(), sliceOf.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestTypePtrTo(t *testing.T) {
+	_ = new(testNamed) // ensure *testNamed is present in the binary
+	if got := TypeOf(testNamed(0)).PtrTo(); got != TypeOf(new(testNamed)) {
+		t.Errorf("PtrTo() = %v, want *abi.testNamed", got)
+	}
+
+	rt := reflect.StructOf([]reflect.StructField{{Name: "X", Type: reflect.TypeFor[int]()}})
+	typ := (*Type)(unsafe.Pointer((*Eface)(unsafe.Pointer(&rt)).Data))
+	if got := typ.PtrTo(); got != nil {
+		t.Errorf("PtrTo() of reflect-created type = %v, want nil", got)
+	}
+}
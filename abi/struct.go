@@ -0,0 +1,59 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// StructField represents a field of a struct type (from internal/abi/type.go).
+type StructField struct {
+	Name   Name    // name is always non-empty
+	Typ    *Type   // type of field
+	Offset uintptr // byte offset of field
+}
+
+// Embedded reports whether f is an embedded (anonymous) field.
+func (f *StructField) Embedded() bool {
+	return f.Name.IsEmbedded()
+}
+
+// StructType represents a struct type (from internal/abi/type.go).
+type StructType struct {
+	Type
+	PkgPath Name
+	Fields  []StructField
+}
+
+// StructType returns t cast to a *StructType, or nil if t is not a struct type.
+func (t *Type) StructType() *StructType {
+	if t.Kind() != Struct {
+		return nil
+	}
+	return (*StructType)(unsafe.Pointer(t))
+}
+
+// NumField returns the number of fields in t.
+func (t *StructType) NumField() int {
+	return len(t.Fields)
+}
+
+// Field returns the i'th field of t.
+//
+// Unlike reflect.Type.Field, it returns a pointer into the type
+// descriptor and does not allocate.
+func (t *StructType) Field(i int) *StructField {
+	return &t.Fields[i]
+}
+
+// AllFields returns an iterator over the index and field of each field in t.
+func (t *StructType) AllFields() iter.Seq2[int, *StructField] {
+	return func(yield func(int, *StructField) bool) {
+		for i := range t.Fields {
+			if !yield(i, &t.Fields[i]) {
+				return
+			}
+		}
+	}
+}
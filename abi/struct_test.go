@@ -0,0 +1,86 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"testing"
+	"unsafe"
+)
+
+type testEmbedded struct {
+	X int
+}
+
+type testStruct struct {
+	testEmbedded
+	Name  string `json:"name"`
+	count int
+	Ptr   *int
+}
+
+func TestStructType(t *testing.T) {
+	t.Run("non-struct", func(t *testing.T) {
+		if st := TypeOf(42).StructType(); st != nil {
+			t.Errorf("Expected nil StructType for int, got %v", st)
+		}
+	})
+
+	st := TypeOf(testStruct{}).StructType()
+	if st == nil {
+		t.Fatal("Expected non-nil StructType")
+	}
+	if st.NumField() != 4 {
+		t.Fatalf("Expected 4 fields, got %d", st.NumField())
+	}
+
+	var v testStruct
+	expected := []struct {
+		name     string
+		tag      string
+		kind     Kind
+		offset   uintptr
+		embedded bool
+		exported bool
+	}{
+		{"testEmbedded", "", Struct, unsafe.Offsetof(v.testEmbedded), true, false},
+		{"Name", `json:"name"`, String, unsafe.Offsetof(v.Name), false, true},
+		{"count", "", Int, unsafe.Offsetof(v.count), false, false},
+		{"Ptr", "", Pointer, unsafe.Offsetof(v.Ptr), false, true},
+	}
+
+	for i, f := range st.AllFields() {
+		want := expected[i]
+		if got := f.Name.Name(); got != want.name {
+			t.Errorf("field %d: expected name %q, got %q", i, want.name, got)
+		}
+		if got := f.Name.Tag(); got != want.tag {
+			t.Errorf("field %d: expected tag %q, got %q", i, want.tag, got)
+		}
+		if got := f.Typ.Kind(); got != want.kind {
+			t.Errorf("field %d: expected kind %v, got %v", i, want.kind, got)
+		}
+		if f.Offset != want.offset {
+			t.Errorf("field %d: expected offset %d, got %d", i, want.offset, f.Offset)
+		}
+		if f.Embedded() != want.embedded {
+			t.Errorf("field %d: expected embedded %v, got %v", i, want.embedded, f.Embedded())
+		}
+		if f.Name.IsExported() != want.exported {
+			t.Errorf("field %d: expected exported %v, got %v", i, want.exported, f.Name.IsExported())
+		}
+		if st.Field(i) != f {
+			t.Errorf("field %d: Field(i) does not match iterator", i)
+		}
+	}
+
+	t.Run("early break", func(t *testing.T) {
+		n := 0
+		for range st.AllFields() {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("Expected iteration to stop after 1 field, got %d", n)
+		}
+	})
+}
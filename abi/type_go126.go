@@ -0,0 +1,18 @@
+//go:build go1.26 && !go1.27 && !gointernals_safe
+
+package abi
+
+// IsDirectIface reports whether t is stored directly in an interface value.
+//
+// Since Go 1.26 this is recorded in TFlagDirectIface.
+func (t *Type) IsDirectIface() bool {
+	return t.TFlag&TFlagDirectIface != 0
+}
+
+// IfaceIndir reports whether t is stored directly in an interface value,
+// the same as IsDirectIface, despite its name.
+//
+// Deprecated: Use IsDirectIface, which is named for what it reports.
+func (t *Type) IfaceIndir() bool {
+	return t.TFlag&TFlagDirectIface != 0
+}
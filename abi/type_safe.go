@@ -0,0 +1,114 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package abi
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Type is an opaque handle to a runtime type descriptor.
+//
+// This is the fallback used on toolchains whose type layout is not mirrored
+// by this package (or with the gointernals_safe build tag). A *Type is still
+// the runtime's type pointer, so it keeps its identity and can be compared,
+// but its fields are not accessible; the methods below go through reflect.
+type Type struct {
+	_ [0]func()
+}
+
+// toReflectType returns the reflect.Type for t.
+func toReflectType(t *Type) reflect.Type {
+	return reflect.TypeOf(*(*any)(unsafe.Pointer(&Eface{Type: t})))
+}
+
+func (t *Type) Kind() Kind {
+	return Kind(toReflectType(t).Kind())
+}
+
+// CanPointer reports whether t contains pointers.
+func (t *Type) CanPointer() bool {
+	return canPointer(toReflectType(t))
+}
+
+func canPointer(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Map, reflect.Chan, reflect.Func,
+		reflect.Slice, reflect.String, reflect.Interface:
+		return true
+	case reflect.Array:
+		return rt.Len() > 0 && canPointer(rt.Elem())
+	case reflect.Struct:
+		for i := range rt.NumField() {
+			if canPointer(rt.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *Type) HasName() bool {
+	return toReflectType(t).Name() != ""
+}
+
+// IsDirectIface reports whether t is stored directly in an interface value.
+func (t *Type) IsDirectIface() bool {
+	return isDirectIface(toReflectType(t))
+}
+
+// IfaceIndir reports whether t is stored directly in an interface value,
+// the same as IsDirectIface, despite its name.
+//
+// Deprecated: Use IsDirectIface, which is named for what it reports.
+func (t *Type) IfaceIndir() bool {
+	return t.IsDirectIface()
+}
+
+// isDirectIface mirrors the compiler's rule: pointer-shaped types, and
+// structs and arrays consisting of a single pointer-shaped element.
+func isDirectIface(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Map, reflect.Chan, reflect.Func:
+		return true
+	case reflect.Array:
+		return rt.Len() == 1 && isDirectIface(rt.Elem())
+	case reflect.Struct:
+		return rt.NumField() == 1 && isDirectIface(rt.Field(0).Type)
+	}
+	return false
+}
+
+// String returns the string form of t, as printed by reflect.Type.String.
+func (t *Type) String() string {
+	return toReflectType(t).String()
+}
+
+// Name returns the type's name within its package for a defined type.
+func (t *Type) Name() string {
+	return toReflectType(t).Name()
+}
+
+// PkgPath returns a defined type's package path.
+func (t *Type) PkgPath() string {
+	return toReflectType(t).PkgPath()
+}
+
+// Len returns the length of t if t is an array type, otherwise 0.
+func (t *Type) Len() int {
+	rt := toReflectType(t)
+	if rt.Kind() != reflect.Array {
+		return 0
+	}
+	return rt.Len()
+}
+
+// Elem returns the element type for t if t is an array, channel, map, pointer, or slice, otherwise nil.
+func (t *Type) Elem() *Type {
+	switch rt := toReflectType(t); rt.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Pointer, reflect.Slice:
+		elem := rt.Elem()
+		return (*Type)(NoEscape((*Eface)(unsafe.Pointer(&elem)).Data))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package abi
+
+import "testing"
+
+func typeOf[T any]() *Type {
+	var v T
+	return TypeOf(&v).Elem()
+}
+
+func TestSafeType(t *testing.T) {
+	type named struct{ p *int }
+
+	tests := []struct {
+		typ      *Type
+		kind     Kind
+		str      string
+		direct   bool
+		pointers bool
+	}{
+		{typeOf[int](), Int, "int", false, false},
+		{typeOf[*int](), Pointer, "*int", true, true},
+		{typeOf[named](), Struct, "abi.named", true, true},
+		{typeOf[[2]string](), Array, "[2]string", false, true},
+		{typeOf[map[string]int](), Map, "map[string]int", true, true},
+	}
+	for _, tt := range tests {
+		if got := tt.typ.Kind(); got != tt.kind {
+			t.Errorf("%s: Kind = %s, want %s", tt.str, got, tt.kind)
+		}
+		if got := tt.typ.String(); got != tt.str {
+			t.Errorf("String = %q, want %q", got, tt.str)
+		}
+		if got := tt.typ.IsDirectIface(); got != tt.direct {
+			t.Errorf("%s: IsDirectIface = %v, want %v", tt.str, got, tt.direct)
+		}
+		if got := tt.typ.IfaceIndir(); got != tt.direct {
+			t.Errorf("%s: IfaceIndir = %v, want %v", tt.str, got, tt.direct)
+		}
+		if got := tt.typ.CanPointer(); got != tt.pointers {
+			t.Errorf("%s: CanPointer = %v, want %v", tt.str, got, tt.pointers)
+		}
+	}
+
+	if got := typeOf[[2]string]().Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+	if got := typeOf[*int]().Elem(); got != typeOf[int]() {
+		t.Errorf("Elem = %s, want int", got)
+	}
+	if got := typeOf[named]().Name(); got != "named" {
+		t.Errorf("Name = %q, want named", got)
+	}
+}
@@ -0,0 +1,173 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"io"
+	"testing"
+)
+
+func TestArrayType(t *testing.T) {
+	typ := TypeOf([4]string{})
+	at := typ.ArrayType()
+	if at == nil {
+		t.Fatal("Expected non-nil ArrayType")
+	}
+	if at.Len != 4 || typ.Len() != 4 {
+		t.Errorf("Expected len 4, got %d / %d", at.Len, typ.Len())
+	}
+	if at.Elem != TypeOf("") || typ.Elem() != TypeOf("") {
+		t.Error("Expected elem type to be string")
+	}
+	if at.Slice != TypeOf([]string(nil)) {
+		t.Error("Expected slice type to be []string")
+	}
+	if TypeOf(42).ArrayType() != nil {
+		t.Error("Expected nil ArrayType for int")
+	}
+	if TypeOf(42).Len() != 0 {
+		t.Error("Expected Len 0 for int")
+	}
+}
+
+func TestElem(t *testing.T) {
+	intType := TypeOf(0)
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"array", [2]int{}},
+		{"chan", make(chan int)},
+		{"map", map[string]int{}},
+		{"pointer", new(int)},
+		{"slice", []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TypeOf(tt.v).Elem(); got != intType {
+				t.Errorf("Expected elem type int, got %v", got)
+			}
+		})
+	}
+	if TypeOf(0).Elem() != nil {
+		t.Error("Expected nil Elem for int")
+	}
+}
+
+func TestChanType(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		dir  ChanDir
+	}{
+		{"both", make(chan int), BothDir},
+		{"recv", make(<-chan int), RecvDir},
+		{"send", make(chan<- int), SendDir},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typ := TypeOf(tt.v)
+			ct := typ.ChanType()
+			if ct == nil {
+				t.Fatal("Expected non-nil ChanType")
+			}
+			if ct.Dir != tt.dir || typ.ChanDir() != tt.dir {
+				t.Errorf("Expected dir %v, got %v / %v", tt.dir, ct.Dir, typ.ChanDir())
+			}
+			if ct.Elem != TypeOf(0) {
+				t.Error("Expected elem type to be int")
+			}
+		})
+	}
+	if TypeOf(0).ChanType() != nil || TypeOf(0).ChanDir() != InvalidDir {
+		t.Error("Expected nil ChanType and InvalidDir for int")
+	}
+}
+
+type testFunc func(int, string, ...bool) (error, int)
+
+func (testFunc) Method() {}
+
+func TestFuncType(t *testing.T) {
+	t.Run("unnamed", func(t *testing.T) {
+		ft := TypeOf(func(int, string) error { return nil }).FuncType()
+		if ft == nil {
+			t.Fatal("Expected non-nil FuncType")
+		}
+		if ft.NumIn() != 2 || ft.NumOut() != 1 {
+			t.Fatalf("Expected 2 in and 1 out, got %d and %d", ft.NumIn(), ft.NumOut())
+		}
+		if ft.In(0) != TypeOf(0) || ft.In(1) != TypeOf("") {
+			t.Error("Unexpected input parameter types")
+		}
+		if ft.Out(0).Kind() != Interface {
+			t.Errorf("Expected output kind interface, got %v", ft.Out(0).Kind())
+		}
+		if ft.IsVariadic() {
+			t.Error("Expected non-variadic func")
+		}
+	})
+
+	t.Run("named with methods", func(t *testing.T) {
+		typ := TypeOf(testFunc(nil))
+		if typ.TFlag&TFlagUncommon == 0 {
+			t.Fatal("Expected TFlagUncommon to be set")
+		}
+		ft := typ.FuncType()
+		if !ft.IsVariadic() {
+			t.Error("Expected variadic func")
+		}
+		ins := []*Type{TypeOf(0), TypeOf(""), TypeOf([]bool(nil))}
+		for i, in := range ft.Ins() {
+			if in != ins[i] {
+				t.Errorf("in %d: unexpected type", i)
+			}
+		}
+		outs := []Kind{Interface, Int}
+		for i, out := range ft.Outs() {
+			if out.Kind() != outs[i] {
+				t.Errorf("out %d: expected kind %v, got %v", i, outs[i], out.Kind())
+			}
+		}
+	})
+
+	t.Run("no params", func(t *testing.T) {
+		ft := TypeOf(func() {}).FuncType()
+		if ft.InSlice() != nil || ft.OutSlice() != nil {
+			t.Error("Expected nil param slices")
+		}
+	})
+
+	if TypeOf(0).FuncType() != nil {
+		t.Error("Expected nil FuncType for int")
+	}
+}
+
+func TestInterfaceType(t *testing.T) {
+	typ := TypeOf((*io.ReadWriteCloser)(nil)).Elem()
+	it := typ.InterfaceType()
+	if it == nil {
+		t.Fatal("Expected non-nil InterfaceType")
+	}
+	if it.NumMethod() != 3 {
+		t.Errorf("Expected 3 methods, got %d", it.NumMethod())
+	}
+	n := 0
+	for i, m := range it.AllMethods() {
+		if m != &it.Methods[i] {
+			t.Errorf("method %d: iterator does not alias Methods", i)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("Expected to iterate 3 methods, got %d", n)
+	}
+
+	empty := TypeOf((*any)(nil)).Elem().InterfaceType()
+	if empty == nil || empty.NumMethod() != 0 {
+		t.Error("Expected empty interface with no methods")
+	}
+	if TypeOf(0).InterfaceType() != nil {
+		t.Error("Expected nil InterfaceType for int")
+	}
+}
@@ -0,0 +1,180 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// Method on non-interface type (from internal/abi/type.go).
+type Method struct {
+	Name NameOff // name of method
+	Mtyp TypeOff // method type (without receiver)
+	Ifn  TextOff // fn used in interface call (one-word receiver)
+	Tfn  TextOff // fn used for normal method call
+}
+
+// UncommonType is present only for defined types or types with methods
+// (if T is a defined type, the uncommonTypes for T and *T have methods).
+// Using a pointer to this struct reduces the overall size required
+// to describe a non-defined type with no methods.
+//
+// (from internal/abi/type.go)
+type UncommonType struct {
+	PkgPath NameOff // import path; empty for built-in types like int, string
+	Mcount  uint16  // number of methods
+	Xcount  uint16  // number of exported methods
+	Moff    uint32  // offset from this uncommontype to [mcount]Method
+	_       uint32  // unused
+}
+
+// Methods returns all methods of t, exported ones first, each group sorted by name.
+//
+// The returned slice aliases the type descriptor and must not be modified.
+func (t *UncommonType) Methods() []Method {
+	if t.Mcount == 0 {
+		return nil
+	}
+	return unsafe.Slice((*Method)(unsafe.Add(unsafe.Pointer(t), t.Moff)), t.Mcount)
+}
+
+// ExportedMethods returns the exported methods of t, sorted by name.
+//
+// The returned slice aliases the type descriptor and must not be modified.
+func (t *UncommonType) ExportedMethods() []Method {
+	if t.Xcount == 0 {
+		return nil
+	}
+	return unsafe.Slice((*Method)(unsafe.Add(unsafe.Pointer(t), t.Moff)), t.Xcount)
+}
+
+// ResolvedMethod is a Method with its offsets resolved against the owning type.
+type ResolvedMethod struct {
+	Name Name
+	Mtyp *Type          // method type (without receiver), nil if unreachable
+	Ifn  unsafe.Pointer // code pointer used in interface call (one-word receiver)
+	Tfn  unsafe.Pointer // code pointer used for normal method call
+}
+
+// ResolveMethod resolves the offsets of m, which must belong to t's method table.
+func (t *Type) ResolveMethod(m *Method) ResolvedMethod {
+	return ResolvedMethod{
+		Name: t.NameOff(m.Name),
+		Mtyp: t.TypeOff(m.Mtyp),
+		Ifn:  t.TextOff(m.Ifn),
+		Tfn:  t.TextOff(m.Tfn),
+	}
+}
+
+// Methods returns an iterator over the index and resolved method of each
+// method of t, exported and unexported.
+//
+// Interface types have no method table; use InterfaceType.AllMethods instead.
+func (t *Type) Methods() iter.Seq2[int, ResolvedMethod] {
+	return func(yield func(int, ResolvedMethod) bool) {
+		ut := t.Uncommon()
+		if ut == nil {
+			return
+		}
+		methods := ut.Methods()
+		for i := range methods {
+			if !yield(i, t.ResolveMethod(&methods[i])) {
+				return
+			}
+		}
+	}
+}
+
+// MethodByName returns the method of t with the given name.
+//
+// Unlike reflect.Type.MethodByName, it also finds unexported methods
+// and does not allocate.
+func (t *Type) MethodByName(name string) (ResolvedMethod, bool) {
+	ut := t.Uncommon()
+	if ut == nil {
+		return ResolvedMethod{}, false
+	}
+	methods := ut.Methods()
+
+	// Exported methods come first and are sorted by name,
+	// the same search as reflect.(*rtype).MethodByName.
+	exported := methods[:ut.Xcount]
+	i, j := 0, len(exported)
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		if t.NameOff(exported[h].Name).Name() < name {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	if i < len(exported) && t.NameOff(exported[i].Name).Name() == name {
+		return t.ResolveMethod(&exported[i]), true
+	}
+
+	for i := int(ut.Xcount); i < len(methods); i++ {
+		if t.NameOff(methods[i].Name).Name() == name {
+			return t.ResolveMethod(&methods[i]), true
+		}
+	}
+	return ResolvedMethod{}, false
+}
+
+// Uncommon returns a pointer to t's "uncommon" data if there is any, otherwise nil.
+func (t *Type) Uncommon() *UncommonType {
+	if t.TFlag&TFlagUncommon == 0 {
+		return nil
+	}
+	switch t.Kind() {
+	case Struct:
+		type u struct {
+			StructType
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	case Pointer, Slice:
+		type u struct {
+			Type
+			Elem *Type
+			u    UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	case Func:
+		type u struct {
+			FuncType
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	case Array:
+		type u struct {
+			ArrayType
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	case Chan:
+		type u struct {
+			ChanType
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	case Map:
+		type u struct {
+			mapType
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	case Interface:
+		type u struct {
+			InterfaceType
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	default:
+		type u struct {
+			Type
+			u UncommonType
+		}
+		return &(*u)(unsafe.Pointer(t)).u
+	}
+}
@@ -0,0 +1,97 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package abi
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testMethods struct{ n int }
+
+func (testMethods) Beta() int         { return 2 }
+func (testMethods) Alpha() int        { return 1 }
+func (testMethods) gamma() int        { return 3 }
+func (*testMethods) PtrOnly() int     { return 4 }
+func (m testMethods) Delta(x int) int { return m.n + x }
+
+var _ = testMethods.gamma
+
+func TestUncommon(t *testing.T) {
+	if TypeOf([]int(nil)).Uncommon() != nil {
+		t.Error("Expected nil UncommonType for []int")
+	}
+
+	ut := TypeOf(testMethods{}).Uncommon()
+	if ut == nil {
+		t.Fatal("Expected non-nil UncommonType")
+	}
+	if ut.Xcount != 3 || ut.Mcount != 4 {
+		t.Errorf("Expected 3 exported and 4 total methods, got %d and %d", ut.Xcount, ut.Mcount)
+	}
+	if len(ut.ExportedMethods()) != 3 || len(ut.Methods()) != 4 {
+		t.Error("Unexpected method slice lengths")
+	}
+
+	named := TypeOf(testNamed(0)).Uncommon()
+	if named == nil || named.Methods() != nil {
+		t.Error("Expected UncommonType without methods for testNamed")
+	}
+}
+
+func TestMethods(t *testing.T) {
+	for _, v := range []any{testMethods{}, &testMethods{}} {
+		rt := reflect.TypeOf(v)
+		typ := TypeOf(v)
+		t.Run(rt.String(), func(t *testing.T) {
+			var names []string
+			for i, m := range typ.Methods() {
+				names = append(names, m.Name.Name())
+				if i >= rt.NumMethod() {
+					continue
+				}
+				rm := rt.Method(i)
+				if m.Name.Name() != rm.Name {
+					t.Errorf("method %d: name %q, want %q", i, m.Name.Name(), rm.Name)
+				}
+				if m.Tfn != rm.Func.UnsafePointer() {
+					t.Errorf("method %d: Tfn %p, want %p", i, m.Tfn, rm.Func.UnsafePointer())
+				}
+				if m.Mtyp == nil || m.Mtyp.Kind() != Func {
+					t.Errorf("method %d: expected func Mtyp", i)
+				}
+			}
+			if len(names) != rt.NumMethod()+1 || names[len(names)-1] != "gamma" {
+				t.Errorf("Unexpected methods %v", names)
+			}
+		})
+	}
+}
+
+func TestMethodByName(t *testing.T) {
+	typ := TypeOf(testMethods{})
+	for _, name := range []string{"Alpha", "Beta", "Delta", "gamma"} {
+		m, ok := typ.MethodByName(name)
+		if !ok {
+			t.Errorf("Expected to find method %q", name)
+			continue
+		}
+		if m.Name.Name() != name {
+			t.Errorf("Expected method %q, got %q", name, m.Name.Name())
+		}
+		if rm, ok := reflect.TypeOf(testMethods{}).MethodByName(name); ok && rm.Func.UnsafePointer() != m.Tfn {
+			t.Errorf("method %q: Tfn does not match reflect", name)
+		}
+	}
+	for _, name := range []string{"PtrOnly", "Zeta", ""} {
+		if _, ok := typ.MethodByName(name); ok {
+			t.Errorf("Expected not to find method %q", name)
+		}
+	}
+	if _, ok := TypeOf(new(testMethods)).MethodByName("PtrOnly"); !ok {
+		t.Error("Expected to find PtrOnly on *testMethods")
+	}
+	if _, ok := TypeOf(0).MethodByName("String"); ok {
+		t.Error("Expected no methods on int")
+	}
+}
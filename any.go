@@ -6,10 +6,6 @@ import (
 	"github.com/yusing/gointernals/abi"
 )
 
-func TypeFor[T any]() *abi.Type {
-	return (*PointerType)(abi.NoEscape(unsafe.Pointer(TypeOf((*T)(nil))))).Elem
-}
-
 func TypeOf(v any) *abi.Type {
 	return abi.TypeOf(v)
 }
@@ -0,0 +1,42 @@
+// Package gointernals exposes Go runtime internals: type descriptors, map
+// and slice headers, and the runtime functions that operate on them.
+//
+// The runtime layouts are mirrored for go1.24 through go1.26. On other
+// toolchains, or with the gointernals_safe build tag, a reflect-based
+// fallback is built instead. It keeps the functions that operate on maps,
+// slices, strings and interface values, but not the mirrors of runtime
+// layouts that it cannot know:
+//
+//   - Hmap, MapExtra, MapTable, PointerType and SliceType, and the fields
+//     of MapType;
+//   - MapGroupSlots, MapGroupSlotsBits and MapCtrlEmpty, and the MapType
+//     flag constants MapIndirectKey, MapIndirectElem, MapNeedKeyUpdate and
+//     MapHashMightPanic (the MapType methods of the same names remain);
+//   - GetITab, EfaceToIface and IfaceToEface;
+//   - in package abi, the fields of Type, the descriptor mirrors ArrayType,
+//     ChanType, FuncType, InterfaceType, StructType, StructField,
+//     UncommonType, Method, Imethod, ResolvedMethod and Name, the Iface and
+//     ITab mirrors, ChanDir and its constants, PtrSize, and the Type methods
+//     ArrayType, ChanDir, ChanType, FuncType, GCMask, InterfaceType,
+//     IsPointerWord, MethodByName, Methods, NameOff, NumPointers,
+//     PointerOffsets, PtrTo, ResolveMethod, StructType, TextOff, TypeOff and
+//     Uncommon.
+//
+// The fallback links to a single runtime function, runtime.mapassign, since
+// reflect has no way to get a settable map element; its signature is fixed
+// by go.dev/issue/67401.
+//
+// On go1.24 and go1.25 with GOEXPERIMENT=noswissmap, [MapType] and [Map]
+// mirror the legacy bucket-based layout ([Hmap]); MapStats, MapInfo,
+// MapTableInfo and the swiss table internals are not available. Neither are
+// they in the fallback.
+//
+// The default build only links to runtime symbols that the linker allows
+// //go:linkname references to, so it needs no extra linker flags. The
+// gointernals_linkname build tag opts into symbols that need
+// -ldflags=-checklinkname=0:
+//
+//   - runtime.getGCMask, used by abi.Type.GCMask and its callers for
+//     types with abi.TFlagGCMaskOnDemand. Without the tag, the bitmask is
+//     built and cached by this package instead.
+package gointernals
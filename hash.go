@@ -0,0 +1,88 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+//go:linkname typehash runtime.typehash
+//go:noescape
+func typehash(t *abi.Type, p unsafe.Pointer, h uintptr) uintptr
+
+//go:linkname memhash runtime.memhash
+//go:noescape
+func memhash(p unsafe.Pointer, h, s uintptr) uintptr
+
+//go:linkname strhash runtime.strhash
+//go:noescape
+func strhash(p unsafe.Pointer, h uintptr) uintptr
+
+// NewHashSeed returns a random seed for Hash and friends.
+func NewHashSeed() uintptr {
+	return uintptr(rand())
+}
+
+// Hash returns the hash of the value of type typ at p, using the runtime's
+// generic typehash. This is the hash used for interface keys and for maps
+// created with reflect.MapOf.
+//
+// It panics if typ is not comparable, or if it contains an interface
+// holding a value of an unhashable dynamic type.
+//
+//go:nosplit
+func Hash(typ *abi.Type, p unsafe.Pointer, seed uintptr) uintptr {
+	if typ.Equal == nil {
+		panic("gointernals.Hash of non-comparable type " + typ.String())
+	}
+	return typehash(typ, p, seed)
+}
+
+// HashOf returns the hash of v, using the hasher of map[T]V so that the
+// result is exactly what the runtime computes for map keys of type T.
+//
+// It panics if v contains an interface holding a value of an unhashable
+// dynamic type.
+//
+//go:nosplit
+func HashOf[T comparable](v T, seed uintptr) uintptr {
+	_, mType := MapUnpack[T, struct{}](nil)
+	return mType.Hasher(abi.NoEscape(unsafe.Pointer(&v)), seed)
+}
+
+// MemHash returns the hash of the n bytes at p.
+//
+//go:nosplit
+func MemHash(p unsafe.Pointer, seed, n uintptr) uintptr {
+	return memhash(p, seed, n)
+}
+
+// StrHash returns the hash of s.
+//
+//go:nosplit
+func StrHash(s string, seed uintptr) uintptr {
+	return strhash(abi.NoEscape(unsafe.Pointer(&s)), seed)
+}
+
+// Equal reports whether the values of type typ at a and b are equal,
+// with the semantics of the == operator.
+//
+// It panics if typ is not comparable.
+//
+//go:nosplit
+func Equal(typ *abi.Type, a, b unsafe.Pointer) bool {
+	if typ.Equal == nil {
+		panic("gointernals.Equal of non-comparable type " + typ.String())
+	}
+	return typ.Equal(a, b)
+}
+
+// strMapHash returns the hash of key computed by the hasher of the
+// string-keyed map type mType.
+//
+//go:nosplit
+func strMapHash(mType *MapType, key string, seed uintptr) uintptr {
+	return mType.Hasher(abi.NoEscape(unsafe.Pointer(&key)), seed)
+}
@@ -0,0 +1,90 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"hash/maphash"
+	"math/rand/v2"
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// hashSeed is the process-wide maphash seed. The uintptr seeds taken by the
+// functions below are mixed in by hashing them first.
+var hashSeed = maphash.MakeSeed()
+
+// seedHash resets h and seeds it with hashSeed and seed.
+func seedHash(h *maphash.Hash, seed uintptr) {
+	h.SetSeed(hashSeed)
+	maphash.WriteComparable(h, seed)
+}
+
+// NewHashSeed returns a random seed for Hash and friends.
+func NewHashSeed() uintptr {
+	return uintptr(rand.Uint64())
+}
+
+// Hash returns the hash of the value of type typ at p.
+//
+// In the fallback build this is hash/maphash rather than the runtime's
+// typehash, so results differ from the fast build.
+//
+// It panics if typ is not comparable, or if it contains an interface
+// holding a value of an unhashable dynamic type.
+func Hash(typ *abi.Type, p unsafe.Pointer, seed uintptr) uintptr {
+	rt := ABITypeToReflectType(typ)
+	if !rt.Comparable() {
+		panic("gointernals.Hash of non-comparable type " + typ.String())
+	}
+	var h maphash.Hash
+	seedHash(&h, seed)
+	maphash.WriteComparable(&h, reflect.NewAt(rt, p).Elem().Interface())
+	return uintptr(h.Sum64())
+}
+
+// HashOf returns the hash of v.
+//
+// It panics if v contains an interface holding a value of an unhashable
+// dynamic type.
+func HashOf[T comparable](v T, seed uintptr) uintptr {
+	var h maphash.Hash
+	seedHash(&h, seed)
+	// Hash as any so the result agrees with Hash.
+	maphash.WriteComparable[any](&h, v)
+	return uintptr(h.Sum64())
+}
+
+// MemHash returns the hash of the n bytes at p.
+func MemHash(p unsafe.Pointer, seed, n uintptr) uintptr {
+	var h maphash.Hash
+	seedHash(&h, seed)
+	h.Write(unsafe.Slice((*byte)(p), n))
+	return uintptr(h.Sum64())
+}
+
+// StrHash returns the hash of s.
+func StrHash(s string, seed uintptr) uintptr {
+	var h maphash.Hash
+	seedHash(&h, seed)
+	h.WriteString(s)
+	return uintptr(h.Sum64())
+}
+
+// Equal reports whether the values of type typ at a and b are equal,
+// with the semantics of the == operator.
+//
+// It panics if typ is not comparable.
+func Equal(typ *abi.Type, a, b unsafe.Pointer) bool {
+	rt := ABITypeToReflectType(typ)
+	if !rt.Comparable() {
+		panic("gointernals.Equal of non-comparable type " + typ.String())
+	}
+	return reflect.NewAt(rt, a).Elem().Interface() == reflect.NewAt(rt, b).Elem().Interface()
+}
+
+// strMapHash returns the hash of key for the string-keyed map type mType.
+func strMapHash(_ *MapType, key string, seed uintptr) uintptr {
+	return StrHash(key, seed)
+}
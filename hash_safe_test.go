@@ -0,0 +1,52 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"fmt"
+	"testing"
+	"unsafe"
+)
+
+func TestSafeHash(t *testing.T) {
+	seed := NewHashSeed()
+	s := "hello"
+	if StrHash(s, seed) != StrHash("hel"+"lo", seed) {
+		t.Error("StrHash not deterministic")
+	}
+	n := 42
+	if HashOf(n, seed) != Hash(TypeFor[int](), unsafe.Pointer(&n), seed) {
+		t.Error("HashOf and Hash disagree")
+	}
+	if HashOf(1, seed) == HashOf(1, seed+1) {
+		t.Error("seed has no effect")
+	}
+
+	a, b := [2]int{1, 2}, [2]int{1, 2}
+	if !Equal(TypeFor[[2]int](), unsafe.Pointer(&a), unsafe.Pointer(&b)) {
+		t.Error("Equal of equal arrays = false")
+	}
+}
+
+func TestSafeTypeSwitch(t *testing.T) {
+	s := NewTypeSwitch[string]()
+	TypeSwitchCase(s, func(i int) string { return fmt.Sprint("int ", i) })
+	s.Default(func(any) string { return "other" })
+
+	if got, _ := s.Switch(1); got != "int 1" {
+		t.Errorf("Switch(1) = %q", got)
+	}
+	if got, _ := s.Switch("x"); got != "other" {
+		t.Errorf("Switch(x) = %q", got)
+	}
+	if !Is[fmt.Stringer](stringerT{}) || Is[int]("x") {
+		t.Error("Is returned wrong result")
+	}
+	if _, ok := IfaceFrom[fmt.Stringer](stringerT{}); !ok {
+		t.Error("IfaceFrom failed")
+	}
+}
+
+type stringerT struct{}
+
+func (stringerT) String() string { return "" }
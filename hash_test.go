@@ -0,0 +1,131 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"testing"
+	"unsafe"
+)
+
+type hashKey struct {
+	A int
+	B string
+	C any
+}
+
+// mapHash calls mType.Hasher. It is not inlined so that the compiler cannot
+// resolve the hasher of a statically known map type at compile time, which
+// crashes the go1.26.0 linker.
+//
+//go:noinline
+func mapHash(mType *MapType, p unsafe.Pointer, seed uintptr) uintptr {
+	return mType.Hasher(p, seed)
+}
+
+func TestHashMatchesMapHasher(t *testing.T) {
+	seed := NewHashSeed()
+
+	t.Run("string", func(t *testing.T) {
+		_, mType := MapUnpack(map[string]int{})
+		s := "hello"
+		want := mapHash(mType, unsafe.Pointer(&s), seed)
+		if got := HashOf(s, seed); got != want {
+			t.Errorf("HashOf = %x, want %x", got, want)
+		}
+		if got := StrHash(s, seed); got != want {
+			t.Errorf("StrHash = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		_, mType := MapUnpack(map[int64]int{})
+		v := int64(42)
+		want := mapHash(mType, unsafe.Pointer(&v), seed)
+		if got := HashOf(v, seed); got != want {
+			t.Errorf("HashOf = %x, want %x", got, want)
+		}
+		if got := Hash(TypeFor[int64](), unsafe.Pointer(&v), seed); got != want {
+			t.Errorf("Hash = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		_, mType := MapUnpack(map[hashKey]int{})
+		v := hashKey{A: 1, B: "b", C: 3.5}
+		want := mapHash(mType, unsafe.Pointer(&v), seed)
+		if got := HashOf(v, seed); got != want {
+			t.Errorf("HashOf = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("interface", func(t *testing.T) {
+		_, mType := MapUnpack(map[any]int{})
+		var v any = "x"
+		want := mapHash(mType, unsafe.Pointer(&v), seed)
+		if got := HashOf(v, seed); got != want {
+			t.Errorf("HashOf = %x, want %x", got, want)
+		}
+	})
+}
+
+func TestHash(t *testing.T) {
+	seed := NewHashSeed()
+	b := []byte("hello world")
+	if MemHash(unsafe.Pointer(&b[0]), seed, uintptr(len(b))) != StrHash("hello world", seed) {
+		t.Error("Expected MemHash and StrHash to agree on the same bytes")
+	}
+	if HashOf("a", seed) == HashOf("b", seed) {
+		t.Error("Expected different hashes for different strings")
+	}
+	if HashOf(hashKey{B: "x"}, seed) != HashOf(hashKey{B: MakeStringCopy("x")}, seed) {
+		t.Error("Expected equal hashes for equal values")
+	}
+
+	t.Run("unhashable dynamic type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for unhashable dynamic type")
+			}
+		}()
+		HashOf[any]([]int{1}, seed)
+	})
+
+	t.Run("non-comparable type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for non-comparable type")
+			}
+		}()
+		s := []int{1}
+		Hash(TypeFor[[]int](), unsafe.Pointer(&s), seed)
+	})
+}
+
+func TestEqual(t *testing.T) {
+	a := hashKey{A: 1, B: "b", C: 2}
+	b := hashKey{A: 1, B: MakeStringCopy("b"), C: 2}
+	c := hashKey{A: 1, B: "b", C: 3}
+	typ := TypeFor[hashKey]()
+	if !Equal(typ, unsafe.Pointer(&a), unsafe.Pointer(&b)) {
+		t.Error("Expected a == b")
+	}
+	if Equal(typ, unsafe.Pointer(&a), unsafe.Pointer(&c)) {
+		t.Error("Expected a != c")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for non-comparable type")
+		}
+	}()
+	s := []int{1}
+	Equal(TypeFor[[]int](), unsafe.Pointer(&s), unsafe.Pointer(&s))
+}
+
+func BenchmarkHashOf(b *testing.B) {
+	seed := NewHashSeed()
+	b.ReportAllocs()
+	for b.Loop() {
+		HashOf("some moderately long key", seed)
+	}
+}
@@ -0,0 +1,134 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+//go:linkname getitab runtime.getitab
+//go:noescape
+func getitab(inter *abi.Type, typ *abi.Type, canfail bool) *abi.ITab
+
+// GetITab returns the itab for the interface type inter and the concrete type typ.
+//
+// If typ does not implement inter, GetITab returns nil when canFail is true,
+// and panics with a *runtime.TypeAssertionError otherwise.
+// Itabs are cached by the runtime, so repeated lookups do not allocate.
+//
+// inter must be a non-empty interface type; empty interfaces have no itab.
+//
+//go:nosplit
+func GetITab(inter, typ *abi.Type, canFail bool) *abi.ITab {
+	if inter.Kind() != abi.Interface {
+		panic("gointernals.GetITab of non-interface type " + inter.String())
+	}
+	if inter.InterfaceType().NumMethod() == 0 {
+		panic("gointernals.GetITab of empty interface type " + inter.String())
+	}
+	return getitab(inter, typ, canFail)
+}
+
+// Implements reports whether the type typ implements the interface type inter.
+//
+// It is the equivalent of reflect.Type.Implements without going through reflect.
+func Implements(typ, inter *abi.Type) bool {
+	if inter.Kind() != abi.Interface {
+		panic("gointernals.Implements of non-interface type " + inter.String())
+	}
+	it := inter.InterfaceType()
+	if it.NumMethod() == 0 {
+		return true
+	}
+	if typ.Kind() == abi.Interface {
+		return interfaceImplements(it, typ)
+	}
+	return getitab(inter, typ, true) != nil
+}
+
+// interfaceImplements reports whether the method set of the interface type v
+// contains the methods of t. Both method lists are sorted by name.
+func interfaceImplements(t *abi.InterfaceType, V *abi.Type) bool {
+	v := V.InterfaceType()
+	i := 0
+	for j := range v.Methods {
+		tm := &t.Methods[i]
+		tmName := t.NameOff(tm.Name)
+		vm := &v.Methods[j]
+		vmName := V.NameOff(vm.Name)
+		if vmName.Name() == tmName.Name() && V.TypeOff(vm.Typ) == t.TypeOff(tm.Typ) {
+			if !tmName.IsExported() {
+				tmPkgPath := tmName.PkgPath()
+				if tmPkgPath == "" {
+					tmPkgPath = t.PkgPath.Name()
+				}
+				vmPkgPath := vmName.PkgPath()
+				if vmPkgPath == "" {
+					vmPkgPath = v.PkgPath.Name()
+				}
+				if tmPkgPath != vmPkgPath {
+					continue
+				}
+			}
+			if i++; i >= len(t.Methods) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IfaceToEface converts the non-empty interface value i to an empty interface value.
+//
+//go:nosplit
+func IfaceToEface(i *abi.Iface) abi.Eface {
+	if i.Tab == nil {
+		return abi.Eface{}
+	}
+	return abi.Eface{Type: i.Tab.Type, Data: i.Data}
+}
+
+// EfaceToIface converts the empty interface value e to a value of the
+// interface type inter, with the semantics of a comma-ok type assertion.
+//
+// It reports false if e is nil or its dynamic type does not implement inter.
+//
+//go:nosplit
+func EfaceToIface(e *abi.Eface, inter *abi.Type) (abi.Iface, bool) {
+	if e.Type == nil {
+		return abi.Iface{}, false
+	}
+	tab := GetITab(inter, e.Type, true)
+	if tab == nil {
+		return abi.Iface{}, false
+	}
+	return abi.Iface{Tab: tab, Data: e.Data}, true
+}
+
+// IfaceFrom converts v to the interface type I, with the semantics of
+// the type assertion v.(I) but without going through reflect.
+//
+// I must be an interface type.
+//
+//go:nosplit
+func IfaceFrom[I any](v any) (ret I, ok bool) {
+	inter := TypeFor[I]()
+	if inter.Kind() != abi.Interface {
+		panic("gointernals.IfaceFrom of non-interface type " + inter.String())
+	}
+	if inter.InterfaceType().NumMethod() == 0 {
+		if v == nil {
+			return ret, false
+		}
+		*(*any)(unsafe.Pointer(&ret)) = v
+		return ret, true
+	}
+	iface, ok := EfaceToIface((*abi.Eface)(abi.NoEscape(unsafe.Pointer(&v))), inter)
+	if !ok {
+		return ret, false
+	}
+	*(*abi.Iface)(unsafe.Pointer(&ret)) = iface
+	return ret, true
+}
@@ -0,0 +1,28 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"github.com/yusing/gointernals/abi"
+)
+
+// Implements reports whether the type typ implements the interface type inter.
+func Implements(typ, inter *abi.Type) bool {
+	if inter.Kind() != abi.Interface {
+		panic("gointernals.Implements of non-interface type " + inter.String())
+	}
+	return ABITypeToReflectType(typ).Implements(ABITypeToReflectType(inter))
+}
+
+// IfaceFrom converts v to the interface type I, with the semantics of
+// the type assertion v.(I).
+//
+// I must be an interface type.
+func IfaceFrom[I any](v any) (ret I, ok bool) {
+	inter := TypeFor[I]()
+	if inter.Kind() != abi.Interface {
+		panic("gointernals.IfaceFrom of non-interface type " + inter.String())
+	}
+	ret, ok = v.(I)
+	return ret, ok
+}
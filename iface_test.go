@@ -0,0 +1,163 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+type ifaceStringer struct{ s string }
+
+func (v ifaceStringer) String() string { return v.s }
+
+type ifaceReadStringer interface {
+	io.Reader
+	fmt.Stringer
+}
+
+func TestGetITab(t *testing.T) {
+	inter := TypeFor[fmt.Stringer]()
+
+	t.Run("implements", func(t *testing.T) {
+		typ := TypeFor[ifaceStringer]()
+		tab := GetITab(inter, typ, false)
+		if tab == nil {
+			t.Fatal("Expected non-nil itab")
+		}
+		if tab.Type != typ {
+			t.Error("Expected itab type to match")
+		}
+		if tab.Fun[0] == 0 {
+			t.Error("Expected non-zero method pointer")
+		}
+		if GetITab(inter, typ, false) != tab {
+			t.Error("Expected cached itab on repeated lookup")
+		}
+	})
+
+	t.Run("does not implement", func(t *testing.T) {
+		if tab := GetITab(inter, TypeFor[int](), true); tab != nil {
+			t.Errorf("Expected nil itab, got %v", tab)
+		}
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic when canFail is false")
+			}
+		}()
+		GetITab(inter, TypeFor[int](), false)
+	})
+
+	t.Run("empty interface", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for empty interface")
+			}
+		}()
+		GetITab(TypeFor[any](), TypeFor[int](), true)
+	})
+}
+
+func TestImplements(t *testing.T) {
+	tests := []struct {
+		name  string
+		typ   *abi.Type
+		inter *abi.Type
+		want  bool
+	}{
+		{"value receiver", TypeFor[ifaceStringer](), TypeFor[fmt.Stringer](), true},
+		{"pointer to value receiver", TypeFor[*ifaceStringer](), TypeFor[fmt.Stringer](), true},
+		{"no methods", TypeFor[int](), TypeFor[fmt.Stringer](), false},
+		{"empty interface", TypeFor[int](), TypeFor[any](), true},
+		{"pointer receiver", TypeFor[*strings.Reader](), TypeFor[io.Reader](), true},
+		{"pointer receiver on value", TypeFor[strings.Reader](), TypeFor[io.Reader](), false},
+		{"interface subset", TypeFor[io.ReadCloser](), TypeFor[io.Reader](), true},
+		{"interface superset", TypeFor[io.Reader](), TypeFor[io.ReadCloser](), false},
+		{"interface mixed", TypeFor[ifaceReadStringer](), TypeFor[fmt.Stringer](), true},
+		{"error", TypeFor[error](), TypeFor[fmt.Stringer](), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Implements(tt.typ, tt.inter); got != tt.want {
+				t.Errorf("Implements(%s, %s) = %v, want %v", tt.typ, tt.inter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIfaceFrom(t *testing.T) {
+	t.Run("implements", func(t *testing.T) {
+		s, ok := IfaceFrom[fmt.Stringer](ifaceStringer{"hello"})
+		if !ok {
+			t.Fatal("Expected ok")
+		}
+		if s.String() != "hello" {
+			t.Errorf("Expected %q, got %q", "hello", s.String())
+		}
+	})
+
+	t.Run("direct iface", func(t *testing.T) {
+		err := errors.New("boom")
+		e, ok := IfaceFrom[error](any(err))
+		if !ok || e != err {
+			t.Errorf("Expected %v, got %v (ok=%v)", err, e, ok)
+		}
+	})
+
+	t.Run("does not implement", func(t *testing.T) {
+		s, ok := IfaceFrom[fmt.Stringer](42)
+		if ok || s != nil {
+			t.Errorf("Expected nil and false, got %v and %v", s, ok)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		if _, ok := IfaceFrom[fmt.Stringer](nil); ok {
+			t.Error("Expected false for nil")
+		}
+		if _, ok := IfaceFrom[any](nil); ok {
+			t.Error("Expected false for nil any")
+		}
+	})
+
+	t.Run("empty interface", func(t *testing.T) {
+		v, ok := IfaceFrom[any](42)
+		if !ok || v != 42 {
+			t.Errorf("Expected 42 and true, got %v and %v", v, ok)
+		}
+	})
+
+	t.Run("non-interface", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for non-interface type")
+			}
+		}()
+		IfaceFrom[int](42)
+	})
+}
+
+func TestIfaceToEface(t *testing.T) {
+	var s fmt.Stringer = ifaceStringer{"x"}
+	iface := *(*abi.Iface)(abi.NoEscape(unsafe.Pointer(&s)))
+	e := IfaceToEface(&iface)
+	if got := AnyFrom(&e); got != any(ifaceStringer{"x"}) {
+		t.Errorf("Expected %v, got %v", ifaceStringer{"x"}, got)
+	}
+
+	back, ok := EfaceToIface(&e, TypeFor[fmt.Stringer]())
+	if !ok || back != iface {
+		t.Error("Expected round trip to produce the same iface")
+	}
+
+	var nilIface abi.Iface
+	if e := IfaceToEface(&nilIface); e.Type != nil || e.Data != nil {
+		t.Error("Expected zero eface for nil iface")
+	}
+}
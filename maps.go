@@ -1,4 +1,4 @@
-//go:build go1.24 && !go1.27
+//go:build go1.24 && !go1.27 && !gointernals_safe
 
 package gointernals
 
@@ -8,28 +8,14 @@ import (
 	"github.com/yusing/gointernals/abi"
 )
 
-// Map constants common to several packages
-// runtime/runtime-gdb.py:MapTypePrinter contains its own copy
+// Maximum key or elem size to keep inline (instead of mallocing per element).
+// Must fit in a uint8.
 const (
-	// Number of bits in the group.slot count.
-	MapGroupSlotsBits = 3
-
-	// Number of slots in a group.
-	MapGroupSlots = 1 << MapGroupSlotsBits // 8
-
-	// Maximum key or elem size to keep inline (instead of mallocing per element).
-	// Must fit in a uint8.
 	MapMaxKeyBytes  = 128
 	MapMaxElemBytes = 128
-
-	ctrlEmpty = 0b10000000
-	bitsetLSB = 0x0101010101010101
-
-	// Value of control word with all empty slots.
-	MapCtrlEmpty = bitsetLSB * uint64(ctrlEmpty)
 )
 
-// Hmap is the header for a Go map
+// Hmap is the header for a Go map with the legacy (noswissmap) layout.
 type Hmap struct {
 	// Note: the format of the hmap is also encoded in cmd/compile/internal/gc/reflect.go.
 	// Make sure this stays in sync with the compiler's definition.
@@ -42,6 +28,7 @@ type Hmap struct {
 	Buckets    unsafe.Pointer // array of 2^B Buckets. may be nil if count==0.
 	Oldbuckets unsafe.Pointer // previous bucket array of half the size, non-nil only when growing
 	Nevacuate  uintptr        // progress counter for evacuation (buckets less than this have been evacuated)
+	ClearSeq   uint64
 
 	Extra *MapExtra // optional fields
 }
@@ -52,155 +39,18 @@ type MapExtra struct {
 	NextOverflow unsafe.Pointer
 }
 
-type MapType struct {
-	abi.Type
-	Key   *abi.Type
-	Elem  *abi.Type
-	Group *abi.Type // internal type representing a slot group
-	// function for hashing keys (ptr to key, seed) -> hash
-	Hasher    func(unsafe.Pointer, uintptr) uintptr
-	GroupSize uintptr // == Group.Size_
-	SlotSize  uintptr // size of key/elem slot
-	ElemOff   uintptr // offset of elem in key/elem slot
-	Flags     uint32
-}
-
-// Flag values
-const (
-	MapNeedKeyUpdate = 1 << iota
-	MapHashMightPanic
-	MapIndirectKey
-	MapIndirectElem
-)
-
-func (mt *MapType) NeedKeyUpdate() bool { // true if we need to update key on an overwrite
-	return mt.Flags&MapNeedKeyUpdate != 0
-}
-func (mt *MapType) HashMightPanic() bool { // true if hash function might panic
-	return mt.Flags&MapHashMightPanic != 0
-}
-func (mt *MapType) IndirectKey() bool { // store ptr to key instead of key itself
-	return mt.Flags&MapIndirectKey != 0
-}
-func (mt *MapType) IndirectElem() bool { // store ptr to elem instead of elem itself
-	return mt.Flags&MapIndirectElem != 0
-}
-
-type Map struct {
-	// The number of filled slots (i.e. the number of elements in all
-	// tables). Excludes deleted slots.
-	// Must be first (known by the compiler, for len() builtin).
-	used uint64
-
-	// seed is the hash seed, computed as a unique random number per map.
-	seed uintptr
-
-	// The directory of tables.
-	//
-	// Normally dirPtr points to an array of table pointers
-	//
-	// dirPtr *[dirLen]*table
-	//
-	// The length (dirLen) of this array is `1 << globalDepth`. Multiple
-	// entries may point to the same table. See top-level comment for more
-	// details.
-	//
-	// Small map optimization: if the map always contained
-	// abi.MapGroupSlots or fewer entries, it fits entirely in a
-	// single group. In that case dirPtr points directly to a single group.
-	//
-	// dirPtr *group
-	//
-	// In this case, dirLen is 0. used counts the number of used slots in
-	// the group. Note that small maps never have deleted slots (as there
-	// is no probe sequence to maintain).
-	dirPtr unsafe.Pointer
-	dirLen int
-
-	// The number of bits to use in table directory lookups.
-	globalDepth uint8
-
-	// The number of bits to shift out of the hash for directory lookups.
-	// On 64-bit systems, this is 64 - globalDepth.
-	globalShift uint8
-
-	// writing is a flag that is toggled (XOR 1) while the map is being
-	// written. Normally it is set to 1 when writing, but if there are
-	// multiple concurrent writers, then toggling increases the probability
-	// that both sides will detect the race.
-	writing uint8
-
-	// tombstonePossible is false if we know that no table in this map
-	// contains a tombstone.
-	tombstonePossible bool
-
-	// clearSeq is a sequence counter of calls to Clear. It is used to
-	// detect map clears during iteration.
-	clearSeq uint64
-}
-
-// MapTable is a Swiss MapTable hash MapTable structure.
-//
-// Each MapTable is a complete hash MapTable implementation.
-//
-// Map uses one or more tables to store entries. Extendible hashing (hash
-// prefix) is used to select the MapTable to use for a specific key. Using
-// multiple tables enables incremental growth by growing only one MapTable at a
-// time.
-type MapTable struct {
-	// The number of filled slots (i.e. the number of elements in the table).
-	used uint16
-
-	// The total number of slots (always 2^N). Equal to
-	// `(groups.lengthMask+1)*abi.MapGroupSlots`.
-	capacity uint16
-
-	// The number of slots we can still fill without needing to rehash.
-	//
-	// We rehash when used + tombstones > loadFactor*capacity, including
-	// tombstones so the table doesn't overfill with tombstones. This field
-	// counts down remaining empty slots before the next rehash.
-	growthLeft uint16
-
-	// The number of bits used by directory lookups above this table. Note
-	// that this may be less then globalDepth, if the directory has grown
-	// but this table has not yet been split.
-	localDepth uint8
-
-	// Index of this table in the Map directory. This is the index of the
-	// _first_ location in the directory. The table may occur in multiple
-	// sequential indices.
-	//
-	// index is -1 if the table is stale (no longer installed in the
-	// directory).
-	index int
-
-	// groups is an array of slot groups. Each group holds abi.MapGroupSlots
-	// key/elem slots and their control bytes. A table has a fixed size
-	// groups array. The table is replaced (in rehash) when more space is
-	// required.
-	//
-	// TODO(prattmic): keys and elements are interleaved to maximize
-	// locality, but it comes at the expense of wasted space for some types
-	// (consider uint8 key, uint64 element). Consider placing all keys
-	// together in these cases to save space.
-	groups unsafe.Pointer
-}
-
 //go:linkname mapclone maps.clone
 //go:noescape
 func mapclone(m any) any
 
-//go:linkname mapaccess1_faststr runtime.mapaccess1_faststr
-//go:noescape
-func mapaccess1_faststr(t *MapType, m *Map, ky string) unsafe.Pointer
-
-//go:linkname mapaccess2_faststr
+//go:linkname mapaccess2_faststr runtime.mapaccess2_faststr
 //go:noescape
 func mapaccess2_faststr(t *MapType, m *Map, ky string) (unsafe.Pointer, bool)
 
+// mapassign_faststr stores s in the map, so it must not be marked
+// go:noescape.
+//
 //go:linkname mapassign_faststr runtime.mapassign_faststr
-//go:noescape
 func mapassign_faststr(t *MapType, m *Map, s string) unsafe.Pointer
 
 //go:linkname mapassign runtime.mapassign
@@ -212,10 +62,35 @@ type (
 	StrMapSetFunc = func(m *Map, mType *MapType, key string, value unsafe.Pointer)
 )
 
+// strmapaccess is mapaccess2_faststr, except for maps with indirect
+// elements, which the faststr routines do not support.
+//
+//go:nosplit
+func strmapaccess(mType *MapType, m *Map, key string) (unsafe.Pointer, bool) {
+	if mType.IndirectElem() {
+		return mapaccess2(mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+	}
+	return mapaccess2_faststr(mType, m, key)
+}
+
+// strmapassign is mapassign_faststr, except for maps with indirect
+// elements, which the faststr routines do not support.
+//
+//go:nosplit
+func strmapassign(mType *MapType, m *Map, key string) unsafe.Pointer {
+	if mType.IndirectElem() {
+		return mapassign(mType, m, unsafe.Pointer(&key))
+	}
+	return mapassign_faststr(mType, m, key)
+}
+
 //go:nosplit
 //go:linkname StrMapGet gointernals.StrMapGet
 func StrMapGet(m *Map, mType *MapType, key string) unsafe.Pointer {
-	return mapaccess1_faststr(mType, m, key)
+	// runtime.mapaccess1_faststr is not linkname-accessible; mapaccess2_faststr
+	// also returns a pointer to the zero value for missing keys.
+	p, _ := strmapaccess(mType, m, key)
+	return p
 }
 
 //go:nosplit
@@ -225,7 +100,7 @@ func StrMapGetAs[K comparable, V any](m *Map, mType *MapType, key string) V {
 
 //go:nosplit
 func StrMapTryGet(m *Map, mType *MapType, key string) (unsafe.Pointer, bool) {
-	return mapaccess2_faststr(mType, m, key)
+	return strmapaccess(mType, m, key)
 }
 
 //go:nosplit
@@ -241,10 +116,68 @@ func StrMapTryGetAs[K comparable, V any](m *Map, mType *MapType, key string) (V,
 //go:nosplit
 //go:linkname StrMapSet gointernals.StrMapSet
 func StrMapSet(m *Map, mType *MapType, key string, value unsafe.Pointer) {
-	dst := mapassign_faststr(mType, m, key)
+	dst := strmapassign(mType, m, key)
+	typedmemmove(mType.Elem, dst, value)
+}
+
+// StrMapSetBytes is StrMapSet for a key given as bytes. The key is
+// copied only when it is inserted, so updating an existing entry does
+// not allocate.
+func StrMapSetBytes(m *Map, mType *MapType, key []byte, value unsafe.Pointer) {
+	dst, ok := strmapaccess(mType, m, bytesView(key))
+	if !ok {
+		dst = strmapassign(mType, m, MakeStringCopy(bytesView(key)))
+	}
 	typedmemmove(mType.Elem, dst, value)
 }
 
+// StrMapGetOrInsert returns a pointer to the element of key in m.
+// If key is not present, a zeroed element is inserted, init is called
+// with a pointer to it, and inserted is true.
+//
+// A hit hashes key once. init must not modify m, since the element
+// pointer is only valid until the next write to m. If init panics, key is
+// deleted again.
+func StrMapGetOrInsert(m *Map, mType *MapType, key string, init func(elem unsafe.Pointer)) (elem unsafe.Pointer, inserted bool) {
+	if p, ok := strmapaccess(mType, m, key); ok {
+		return p, false
+	}
+	elem = strmapassign(mType, m, key)
+	done := false
+	defer func() {
+		if !done {
+			StrMapDelete(m, mType, key)
+		}
+	}()
+	init(elem)
+	done = true
+	return elem, true
+}
+
+// StrMapGetOrInsertAs is like StrMapGetOrInsert but returns the element
+// by value; on a miss the element is set to the result of init.
+func StrMapGetOrInsertAs[K comparable, V any](m *Map, mType *MapType, key string, init func() V) (V, bool) {
+	elem, inserted := StrMapGetOrInsert(m, mType, key, func(elem unsafe.Pointer) {
+		*(*V)(elem) = init()
+	})
+	return *(*V)(elem), inserted
+}
+
+// MapTryAssign returns a pointer to the element of the key at keyPtr in m,
+// inserting a zero element if key is not present.
+//
+// Unlike the runtime it does not panic if key holds, in an interface, a
+// value of an unhashable type; it returns an *ErrUnhashableKey instead and
+// leaves m unchanged. m must not be nil.
+func MapTryAssign(m *Map, mType *MapType, keyPtr unsafe.Pointer) (unsafe.Pointer, error) {
+	if mType.HashMightPanic() {
+		if err := checkHashable(ABITypeToReflectType(mType.Key), keyPtr); err != nil {
+			return nil, err
+		}
+	}
+	return mapassignKey(mType, m, keyPtr), nil
+}
+
 //go:nosplit
 //go:linkname MapClone gointernals.MapClone
 func MapClone(m *Map, mType *MapType) any {
@@ -256,21 +189,36 @@ func MapCloneAs[K comparable, V any](m *Map, mType *MapType) map[K]V {
 	return mapclone(MapToAny(m, mType)).(map[K]V)
 }
 
-//go:nosplit
-//go:linkname MapClear gointernals.MapClear
-func MapClear(m *Map, mType *MapType) {
-	reflect_mapclear(mType, m)
+// MapCloneInto replaces the contents of dst with the entries of src.
+//
+// dst is cleared rather than reallocated, so tables it has already grown
+// are reused when they can hold src. dst must not be nil.
+func MapCloneInto(dst, src *Map, mType *MapType) {
+	if dst == nil {
+		panic("gointernals.MapCloneInto of nil destination map")
+	}
+	if dst == src {
+		return
+	}
+	reflect_mapclear(mType, dst)
+	MapRange(src, mType, func(key, elem unsafe.Pointer) bool {
+		typedmemmove(mType.Elem, mapassign(mType, dst, key), elem)
+		return true
+	})
 }
 
-//go:nosplit
-func MapUnpack[K comparable, V any](m map[K]V) (*Map, *MapType) {
-	eface := EfaceOf(m)
-	return (*Map)(unsafe.Pointer(eface.Data)), (*MapType)(abi.NoEscape(unsafe.Pointer(eface.Type)))
+// MapCloneIntoAs replaces the contents of dst with the entries of src.
+// See MapCloneInto.
+func MapCloneIntoAs[K comparable, V any](dst, src map[K]V) {
+	dstM, mType := MapUnpack(dst)
+	srcM, _ := MapUnpack(src)
+	MapCloneInto(dstM, srcM, mType)
 }
 
 //go:nosplit
-func StrMapCast[K ~string, V any, M ~map[K]V](m M) map[string]V {
-	return *(*map[string]V)(unsafe.Pointer(&m))
+//go:linkname MapClear gointernals.MapClear
+func MapClear(m *Map, mType *MapType) {
+	reflect_mapclear(mType, m)
 }
 
 //go:nosplit
@@ -278,14 +226,3 @@ func StrMapCast[K ~string, V any, M ~map[K]V](m M) map[string]V {
 func MapElemType(mType *MapType) *abi.Type {
 	return mType.Elem
 }
-
-func MapToEface(m *Map, mType *MapType) *abi.Eface {
-	return &abi.Eface{
-		Data: unsafe.Pointer(m),
-		Type: (*abi.Type)(abi.NoEscape(unsafe.Pointer(mType))),
-	}
-}
-
-func MapToAny(m *Map, mType *MapType) any {
-	return AnyFrom(MapToEface(m, mType))
-}
@@ -0,0 +1,15 @@
+package gointernals
+
+import "unsafe"
+
+// StrMapGetBytes is StrMapGet for a key given as bytes.
+// The key is not copied, so a lookup never allocates.
+func StrMapGetBytes(m *Map, mType *MapType, key []byte) unsafe.Pointer {
+	return StrMapGet(m, mType, bytesView(key))
+}
+
+// StrMapTryGetBytes is StrMapTryGet for a key given as bytes.
+// The key is not copied, so a lookup never allocates.
+func StrMapTryGetBytes(m *Map, mType *MapType, key []byte) (unsafe.Pointer, bool) {
+	return StrMapTryGet(m, mType, bytesView(key))
+}
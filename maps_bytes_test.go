@@ -0,0 +1,50 @@
+package gointernals
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestStrMapBytes(t *testing.T) {
+	m := map[string]int{"hello": 1}
+	mtable, mtype := MapUnpack(m)
+
+	buf := []byte("hello")
+	if got := *(*int)(StrMapGetBytes(mtable, mtype, buf)); got != 1 {
+		t.Errorf("StrMapGetBytes(hello) = %d, want 1", got)
+	}
+	if _, ok := StrMapTryGetBytes(mtable, mtype, []byte("nope")); ok {
+		t.Error("StrMapTryGetBytes(nope) found a missing key")
+	}
+
+	v := 2
+	StrMapSetBytes(mtable, mtype, buf, unsafe.Pointer(&v))
+	buf = []byte("world")
+	StrMapSetBytes(mtable, mtype, buf, unsafe.Pointer(&v))
+	StrMapSetBytes(mtable, mtype, nil, unsafe.Pointer(&v))
+	// The map must not alias buf.
+	copy(buf, "XXXXX")
+	if len(m) != 3 || m["hello"] != 2 || m["world"] != 2 || m[""] != 2 {
+		t.Errorf("m = %v, want map[:2 hello:2 world:2]", m)
+	}
+	for k := range m {
+		if k == "XXXXX" {
+			t.Error("inserted key aliases the caller's buffer")
+		}
+	}
+}
+
+func TestStrMapBytesIndirectElem(t *testing.T) {
+	type big struct{ A [200]byte }
+	m := map[string]big{"k": {A: [200]byte{7}}}
+	mtable, mtype := MapUnpack(m)
+
+	if got := (*big)(StrMapGetBytes(mtable, mtype, []byte("k"))).A[0]; got != 7 {
+		t.Errorf("StrMapGetBytes(k).A[0] = %d, want 7", got)
+	}
+	v := big{A: [200]byte{8}}
+	StrMapSetBytes(mtable, mtype, []byte("k"), unsafe.Pointer(&v))
+	if m["k"].A[0] != 8 {
+		t.Errorf(`m["k"].A[0] = %d, want 8`, m["k"].A[0])
+	}
+}
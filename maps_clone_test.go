@@ -0,0 +1,40 @@
+package gointernals
+
+import (
+	"maps"
+	"strconv"
+	"testing"
+)
+
+func TestMapCloneInto(t *testing.T) {
+	src := make(map[string]int)
+	for i := range 100 {
+		src[strconv.Itoa(i)] = i
+	}
+	dst := map[string]int{"stale": -1}
+	MapCloneIntoAs(dst, src)
+	if !maps.Equal(dst, src) {
+		t.Fatalf("dst has %d entries, want a copy of src", len(dst))
+	}
+
+	dst["extra"] = 1
+	if _, ok := src["extra"]; ok {
+		t.Error("dst shares storage with src")
+	}
+
+	MapCloneIntoAs(dst, map[string]int{})
+	if len(dst) != 0 {
+		t.Errorf("len after cloning an empty map = %d, want 0", len(dst))
+	}
+	MapCloneIntoAs(dst, nil)
+	if len(dst) != 0 {
+		t.Errorf("len after cloning a nil map = %d, want 0", len(dst))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MapCloneInto to a nil map did not panic")
+		}
+	}()
+	MapCloneIntoAs(nil, src)
+}
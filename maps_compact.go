@@ -0,0 +1,29 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import "unsafe"
+
+// MapCompact returns a copy of m sized for its current number of entries,
+// and the number of bytes that replacing m with it reclaims.
+//
+// Maps never shrink, so after many deletes m can hold far more memory than
+// its entries need. The result is built with reflect.makemap and a size
+// hint of len(m). reclaimed is the memory held by m minus that held by the
+// copy, counting the header, tables and groups (or buckets) but not keys
+// and elements stored indirectly. It is approximate for the legacy map
+// layout, which only estimates its overflow buckets, and may be zero or
+// negative if m was already compact. A nil m is returned as is.
+//
+// It must not be called concurrently with writes to m.
+func MapCompact(m *Map, mType *MapType) (compacted *Map, reclaimed int) {
+	if m == nil {
+		return nil, 0
+	}
+	compacted = reflect_makemap(mType, mapLen(m))
+	MapRange(m, mType, func(key, elem unsafe.Pointer) bool {
+		typedmemmove(mType.Elem, mapassign(mType, compacted, key), elem)
+		return true
+	})
+	return compacted, int(mapFootprint(m, mType)) - int(mapFootprint(compacted, mType))
+}
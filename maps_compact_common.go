@@ -0,0 +1,12 @@
+package gointernals
+
+import "unsafe"
+
+// MapCompactAs returns a copy of m sized for its current number of entries,
+// and the number of bytes that replacing m with it reclaims.
+// See MapCompact.
+func MapCompactAs[K comparable, V any](m map[K]V) (map[K]V, int) {
+	mtable, mType := MapUnpack(m)
+	compacted, reclaimed := MapCompact(mtable, mType)
+	return *(*map[K]V)(unsafe.Pointer(&compacted)), reclaimed
+}
@@ -0,0 +1,22 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import "reflect"
+
+// MapCompact returns a copy of m sized for its current number of entries.
+//
+// The fallback build cannot measure map footprints and always reports 0
+// bytes reclaimed.
+func MapCompact(m *Map, mType *MapType) (compacted *Map, reclaimed int) {
+	v := mapValue(m, mType)
+	if v.IsNil() {
+		return nil, 0
+	}
+	c := reflect.MakeMapWithSize(v.Type(), v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		c.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return (*Map)(c.UnsafePointer()), 0
+}
@@ -0,0 +1,30 @@
+package gointernals
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMapCompact(t *testing.T) {
+	m := make(map[int]string)
+	for i := range 1000 {
+		m[i] = "v"
+	}
+	for i := 100; i < 1000; i++ {
+		delete(m, i)
+	}
+
+	compacted, _ := MapCompactAs(m)
+	if !maps.Equal(compacted, m) {
+		t.Fatalf("compacted map has %d entries, want %d equal entries", len(compacted), len(m))
+	}
+	compacted[0] = "changed"
+	if m[0] != "v" {
+		t.Error("compacted map shares storage with the original")
+	}
+
+	var nilMap map[int]string
+	if c, reclaimed := MapCompactAs(nilMap); c != nil || reclaimed != 0 {
+		t.Errorf("MapCompactAs(nil) = %v, %d, want nil, 0", c, reclaimed)
+	}
+}
@@ -0,0 +1,192 @@
+package gointernals
+
+import (
+	"math/bits"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// ConcurrentStrMap is a map[string]V safe for concurrent use, split into
+// shards each guarded by its own lock.
+//
+// The shard of a key is picked with the hasher of the map type, seeded per
+// instance, and each shard is a plain Go map.
+//
+// A ConcurrentStrMap must be created with NewConcurrentStrMap; the zero
+// value has no shards and is not usable.
+type ConcurrentStrMap[V any] struct {
+	mType  *MapType
+	seed   uintptr
+	mask   uintptr
+	shards []concurrentStrMapShard[V]
+}
+
+type concurrentStrMapShard[V any] struct {
+	mu sync.RWMutex
+	m  map[string]V
+	// Pad to a cache line to avoid false sharing between shard locks.
+	_ [(64 - (unsafe.Sizeof(sync.RWMutex{})+unsafe.Sizeof(map[string]struct{}(nil)))%64) % 64]byte
+}
+
+// NewConcurrentStrMap returns an empty map with at least shards shards,
+// rounded up to a power of two. If shards <= 0, it uses 4 * GOMAXPROCS.
+func NewConcurrentStrMap[V any](shards int) *ConcurrentStrMap[V] {
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := 1 << bits.Len(uint(shards-1))
+	c := &ConcurrentStrMap[V]{
+		seed:   NewHashSeed(),
+		mask:   uintptr(n - 1),
+		shards: make([]concurrentStrMapShard[V], n),
+	}
+	_, c.mType = MapUnpack[string, V](nil)
+	for i := range c.shards {
+		c.shards[i].m = make(map[string]V)
+	}
+	return c
+}
+
+func (c *ConcurrentStrMap[V]) shard(key string) *concurrentStrMapShard[V] {
+	return &c.shards[strMapHash(c.mType, key, c.seed)&c.mask]
+}
+
+// Get returns the element of key, or the zero value if key is not present.
+func (c *ConcurrentStrMap[V]) Get(key string) V {
+	v, _ := c.TryGet(key)
+	return v
+}
+
+// TryGet is like Get but also reports whether key is present.
+func (c *ConcurrentStrMap[V]) TryGet(key string) (V, bool) {
+	s := c.shard(key)
+	m, _ := MapUnpack(s.m)
+	s.mu.RLock()
+	p, ok := StrMapTryGet(m, c.mType, key)
+	var v V
+	if ok {
+		v = *(*V)(p)
+	}
+	s.mu.RUnlock()
+	return v, ok
+}
+
+// Set sets the element of key to value.
+func (c *ConcurrentStrMap[V]) Set(key string, value V) {
+	// StrMapSet hides value from escape analysis.
+	value = abi.Escape(value)
+	s := c.shard(key)
+	m, _ := MapUnpack(s.m)
+	s.mu.Lock()
+	StrMapSet(m, c.mType, key, unsafe.Pointer(&value))
+	s.mu.Unlock()
+}
+
+// Delete deletes key. It is a no-op if key is not present.
+func (c *ConcurrentStrMap[V]) Delete(key string) {
+	s := c.shard(key)
+	m, _ := MapUnpack(s.m)
+	s.mu.Lock()
+	StrMapDelete(m, c.mType, key)
+	s.mu.Unlock()
+}
+
+// Compute atomically updates the element of key. fn is called with the
+// current element and whether key is present; its result replaces the
+// element, or deletes key if keep is false. Compute returns the new element
+// and whether key is present afterwards.
+//
+// fn is called with the shard locked and must not use c.
+func (c *ConcurrentStrMap[V]) Compute(key string, fn func(old V, loaded bool) (v V, keep bool)) (V, bool) {
+	s := c.shard(key)
+	m, _ := MapUnpack(s.m)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var old V
+	p, loaded := StrMapTryGet(m, c.mType, key)
+	if loaded {
+		old = *(*V)(p)
+	}
+	v, keep := fn(old, loaded)
+	if !keep {
+		if loaded {
+			StrMapDelete(m, c.mType, key)
+		}
+		var zero V
+		return zero, false
+	}
+	v = abi.Escape(v)
+	StrMapSet(m, c.mType, key, unsafe.Pointer(&v))
+	return v, true
+}
+
+// Range calls yield for each entry until yield returns false.
+//
+// Each shard is read-locked while its entries are visited, so yield must
+// not modify c. Entries in other shards may change during the iteration.
+func (c *ConcurrentStrMap[V]) Range(yield func(key string, v V) bool) {
+	for i := range c.shards {
+		s := &c.shards[i]
+		m, _ := MapUnpack(s.m)
+		cont := true
+		s.mu.RLock()
+		MapRange(m, c.mType, func(key, elem unsafe.Pointer) bool {
+			cont = yield(*(*string)(key), *(*V)(elem))
+			return cont
+		})
+		s.mu.RUnlock()
+		if !cont {
+			return
+		}
+	}
+}
+
+// Len returns the number of entries. Concurrent writes to other shards
+// may make the result stale.
+func (c *ConcurrentStrMap[V]) Len() int {
+	n := 0
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Clear deletes all entries.
+func (c *ConcurrentStrMap[V]) Clear() {
+	for i := range c.shards {
+		s := &c.shards[i]
+		m, _ := MapUnpack(s.m)
+		s.mu.Lock()
+		MapClear(m, c.mType)
+		s.mu.Unlock()
+	}
+}
+
+// Clone returns a snapshot of c with the same shard layout. All shards are
+// read-locked while they are copied.
+func (c *ConcurrentStrMap[V]) Clone() *ConcurrentStrMap[V] {
+	clone := &ConcurrentStrMap[V]{
+		mType:  c.mType,
+		seed:   c.seed,
+		mask:   c.mask,
+		shards: make([]concurrentStrMapShard[V], len(c.shards)),
+	}
+	for i := range c.shards {
+		c.shards[i].mu.RLock()
+	}
+	for i := range c.shards {
+		m, _ := MapUnpack(c.shards[i].m)
+		clone.shards[i].m = MapCloneAs[string, V](m, c.mType)
+	}
+	for i := range c.shards {
+		c.shards[i].mu.RUnlock()
+	}
+	return clone
+}
@@ -0,0 +1,70 @@
+package gointernals
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentStrMap(t *testing.T) {
+	c := NewConcurrentStrMap[int](3)
+	if len(c.shards) != 4 {
+		t.Fatalf("len(shards) = %d, want 4", len(c.shards))
+	}
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				key := strconv.Itoa(i)
+				if i%8 == g {
+					c.Set(key, i)
+				}
+				c.Compute("count", func(old int, _ bool) (int, bool) { return old + 1, true })
+				c.Get(key)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.Get("count"); got != 8000 {
+		t.Errorf(`Get("count") = %d, want 8000`, got)
+	}
+	if c.Len() != 1001 {
+		t.Errorf("Len() = %d, want 1001", c.Len())
+	}
+	for i := range 1000 {
+		if v, ok := c.TryGet(strconv.Itoa(i)); !ok || v != i {
+			t.Fatalf("TryGet(%d) = %d, %v", i, v, ok)
+		}
+	}
+
+	clone := c.Clone()
+	c.Delete("count")
+	if _, ok := c.Compute("1", func(int, bool) (int, bool) { return 0, false }); ok {
+		t.Error("Compute with keep == false reported the key present")
+	}
+	if _, ok := c.TryGet("1"); ok {
+		t.Error("Compute with keep == false did not delete the key")
+	}
+	if c.Len() != 999 || clone.Len() != 1001 {
+		t.Errorf("after delete: Len() = %d, clone.Len() = %d, want 999, 1001", c.Len(), clone.Len())
+	}
+
+	n := 0
+	for key, v := range clone.Range {
+		if key != "count" && strconv.Itoa(v) != key {
+			t.Fatalf("Range yielded %q: %d", key, v)
+		}
+		if n++; n == 10 {
+			break
+		}
+	}
+
+	c.Clear()
+	if c.Len() != 0 || clone.Get("count") != 8000 {
+		t.Errorf("after Clear: Len() = %d, clone count = %d", c.Len(), clone.Get("count"))
+	}
+}
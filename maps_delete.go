@@ -0,0 +1,62 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// runtime.mapdelete_faststr, mapdelete_fast32 and mapdelete_fast64 are not
+// linkname-accessible. With swiss maps they only forward to the generic
+// delete with a pointer to the key, so the variants below use
+// runtime.mapdelete (or reflect.mapdelete_faststr) directly at no cost.
+// With the legacy layout the generic delete is equivalent, only slower,
+// except that reflect.mapdelete_faststr (like the faststr routines in
+// general) does not support indirect elements.
+
+//go:linkname mapdelete runtime.mapdelete
+//go:noescape
+func mapdelete(t *MapType, m *Map, key unsafe.Pointer)
+
+//go:linkname reflect_mapdelete_faststr reflect.mapdelete_faststr
+//go:noescape
+func reflect_mapdelete_faststr(t *MapType, m *Map, key string)
+
+// MapDelete deletes the key at keyPtr from m.
+// It is a no-op if m is nil or the key is not present.
+//
+//go:nosplit
+func MapDelete(m *Map, mType *MapType, keyPtr unsafe.Pointer) {
+	mapdelete(mType, m, keyPtr)
+}
+
+//go:nosplit
+func StrMapDelete(m *Map, mType *MapType, key string) {
+	if mType.IndirectElem() {
+		mapdelete(mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+		return
+	}
+	reflect_mapdelete_faststr(mType, m, key)
+}
+
+//go:nosplit
+func Int32MapDelete(m *Map, mType *MapType, key int32) {
+	checkFastKey[int32]("Int32MapDelete", mType)
+	mapdelete(mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+}
+
+//go:nosplit
+func Int64MapDelete(m *Map, mType *MapType, key int64) {
+	checkFastKey[int64]("Int64MapDelete", mType)
+	mapdelete(mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+}
+
+// FastMapDelete deletes key from m.
+//
+// K may be any 4- or 8-byte type of the same size as the map key type.
+func FastMapDelete[K comparable](m *Map, mType *MapType, key K) {
+	checkFastKey[K]("FastMapDelete", mType)
+	mapdelete(mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+}
@@ -0,0 +1,49 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// mapDeleteAt deletes the key stored at keyPtr from m.
+func mapDeleteAt(m *Map, mType *MapType, keyPtr unsafe.Pointer) {
+	v := mapValue(m, mType)
+	if v.IsNil() {
+		return
+	}
+	v.SetMapIndex(mapKeyAt(v, keyPtr), reflect.Value{})
+}
+
+// MapDelete deletes the key at keyPtr from m.
+// It is a no-op if m is nil or the key is not present.
+func MapDelete(m *Map, mType *MapType, keyPtr unsafe.Pointer) {
+	mapDeleteAt(m, mType, keyPtr)
+}
+
+func StrMapDelete(m *Map, mType *MapType, key string) {
+	v := mapValue(m, mType)
+	if v.IsNil() {
+		return
+	}
+	v.SetMapIndex(mapStrKey(v, key), reflect.Value{})
+}
+
+func Int32MapDelete(m *Map, mType *MapType, key int32) {
+	checkFastKey[int32]("Int32MapDelete", mType)
+	mapDeleteAt(m, mType, unsafe.Pointer(&key))
+}
+
+func Int64MapDelete(m *Map, mType *MapType, key int64) {
+	checkFastKey[int64]("Int64MapDelete", mType)
+	mapDeleteAt(m, mType, unsafe.Pointer(&key))
+}
+
+// FastMapDelete deletes key from m.
+//
+// K may be any 4- or 8-byte type of the same size as the map key type.
+func FastMapDelete[K comparable](m *Map, mType *MapType, key K) {
+	checkFastKey[K]("FastMapDelete", mType)
+	mapDeleteAt(m, mType, unsafe.Pointer(&key))
+}
@@ -0,0 +1,124 @@
+package gointernals
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+func TestStrMapDelete(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 50 {
+		m[strconv.Itoa(i)] = i
+	}
+	mtable, mtype := MapUnpack(m)
+	for i := range 25 {
+		StrMapDelete(mtable, mtype, strconv.Itoa(i))
+	}
+	StrMapDelete(mtable, mtype, "missing")
+	if len(m) != 25 {
+		t.Fatalf("len = %d, want 25", len(m))
+	}
+	if _, ok := m["0"]; ok {
+		t.Error("key 0 still present")
+	}
+	if m["49"] != 49 {
+		t.Errorf("m[49] = %d, want 49", m["49"])
+	}
+
+	var nilMap map[string]int
+	mtable, mtype = MapUnpack(nilMap)
+	StrMapDelete(mtable, mtype, "a")
+}
+
+func TestMapDelete(t *testing.T) {
+	type key struct {
+		a string
+		b int
+	}
+	m := map[key]int{{"a", 1}: 1, {"b", 2}: 2}
+	mtable, mtype := MapUnpack(m)
+	k := key{"a", 1}
+	MapDelete(mtable, mtype, unsafe.Pointer(&k))
+	if len(m) != 1 || m[key{"b", 2}] != 2 {
+		t.Errorf("m = %v, want map[{b 2}:2]", m)
+	}
+}
+
+func TestFastMapDelete(t *testing.T) {
+	m32 := map[uint32]int{1: 1, 2: 2}
+	mtable, mtype := MapUnpack(m32)
+	Int32MapDelete(mtable, mtype, 1)
+	if _, ok := m32[1]; ok || len(m32) != 1 {
+		t.Errorf("m32 = %v, want map[2:2]", m32)
+	}
+
+	m64 := map[int64]int{1: 1, 2: 2}
+	mtable, mtype = MapUnpack(m64)
+	Int64MapDelete(mtable, mtype, 2)
+	if _, ok := m64[2]; ok || len(m64) != 1 {
+		t.Errorf("m64 = %v, want map[1:1]", m64)
+	}
+
+	type id uint64
+	mid := map[id]int{7: 7}
+	mtable, mtype = MapUnpack(mid)
+	FastMapDelete(mtable, mtype, id(7))
+	if len(mid) != 0 {
+		t.Errorf("mid = %v, want empty", mid)
+	}
+}
+
+func TestIntMapDeleteKeySizeMismatch(t *testing.T) {
+	mtable, mtype := MapUnpack(map[string]int{"a": 1})
+	for name, fn := range map[string]func(){
+		"Int32MapDelete": func() { Int32MapDelete(mtable, mtype, 1) },
+		"Int64MapDelete": func() { Int64MapDelete(mtable, mtype, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on a string-keyed map did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestReflectMapDelete(t *testing.T) {
+	type myStr string
+	m := map[myStr]int{"a": 1, "b": 2, "c": 3}
+	v := reflect.ValueOf(m)
+
+	ReflectMapDelete(v, myStr("a")) // exact key type
+	ReflectMapDelete(v, "b")        // convertible key type
+	if len(m) != 1 || m["c"] != 3 {
+		t.Errorf("m = %v, want map[c:3]", m)
+	}
+
+	pm := map[*int]int{}
+	p := new(int)
+	pm[p] = 1
+	ReflectMapDelete(reflect.ValueOf(pm), p) // direct-iface key
+	if len(pm) != 0 {
+		t.Errorf("pm = %v, want empty", pm)
+	}
+
+	am := map[any]int{nil: 1, 2: 2}
+	ReflectMapDelete(reflect.ValueOf(am), nil) // nil interface key
+	if _, ok := am[nil]; ok || len(am) != 1 {
+		t.Errorf("am = %v, want map[2:2]", am)
+	}
+
+	var nilMap map[string]int
+	ReflectMapDelete(reflect.ValueOf(nilMap), "a")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("ReflectMapDelete with unassignable key did not panic")
+		}
+	}()
+	ReflectMapDelete(v, 1.5)
+}
@@ -0,0 +1,253 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+//go:linkname mapaccess2 runtime.mapaccess2
+//go:noescape
+func mapaccess2(t *MapType, m *Map, key unsafe.Pointer) (unsafe.Pointer, bool)
+
+//go:linkname mapaccess2_fast32 runtime.mapaccess2_fast32
+//go:noescape
+func mapaccess2_fast32(t *MapType, m *Map, key uint32) (unsafe.Pointer, bool)
+
+//go:linkname mapaccess2_fast64 runtime.mapaccess2_fast64
+//go:noescape
+func mapaccess2_fast64(t *MapType, m *Map, key uint64) (unsafe.Pointer, bool)
+
+//go:linkname mapassign_fast32 runtime.mapassign_fast32
+//go:noescape
+func mapassign_fast32(t *MapType, m *Map, key uint32) unsafe.Pointer
+
+// mapassign_fast32ptr and mapassign_fast64ptr store key in the map, so
+// they must not be marked go:noescape.
+//
+//go:linkname mapassign_fast32ptr runtime.mapassign_fast32ptr
+func mapassign_fast32ptr(t *MapType, m *Map, key unsafe.Pointer) unsafe.Pointer
+
+//go:linkname mapassign_fast64 runtime.mapassign_fast64
+//go:noescape
+func mapassign_fast64(t *MapType, m *Map, key uint64) unsafe.Pointer
+
+//go:linkname mapassign_fast64ptr runtime.mapassign_fast64ptr
+func mapassign_fast64ptr(t *MapType, m *Map, key unsafe.Pointer) unsafe.Pointer
+
+// The Equal functions of 4- and 8-byte types compared by memory equality
+// (runtime.memequal32 and runtime.memequal64).
+var (
+	memequal32 = equalFunc(TypeFor[uint32]())
+	memequal64 = equalFunc(TypeFor[uint64]())
+)
+
+func equalFunc(t *abi.Type) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&t.Equal))
+}
+
+// canFastKey reports whether the runtime's fast32 and fast64 map routines
+// are valid for mType. They compare keys as integers and assume the element
+// is stored inline, so like the compiler we only use them for
+// memory-comparable keys and inline elements.
+func canFastKey(mType *MapType) bool {
+	if mType.IndirectElem() {
+		return false
+	}
+	switch mType.Key.Size {
+	case 4:
+		return equalFunc(mType.Key) == memequal32
+	case 8:
+		return equalFunc(mType.Key) == memequal64
+	}
+	return false
+}
+
+// checkFastKey panics if K is not a 4- or 8-byte type of the same size
+// as the key type of mType.
+func checkFastKey[K comparable](fn string, mType *MapType) {
+	var key K
+	if size := unsafe.Sizeof(key); size != 4 && size != 8 || size != mType.Key.Size {
+		panic("gointernals." + fn + ": key type " + TypeFor[K]().String() + " does not match map key type " + mType.Key.String())
+	}
+}
+
+// mapPathOf returns the runtime map routines to use for mType: the faststr,
+// fast32 or fast64 ones wherever the compiler would use them.
+func mapPathOf(mType *MapType) mapPath {
+	switch {
+	case mType.IndirectElem():
+		return mapPathGeneric
+	case mType.Key.Kind() == abi.String:
+		return mapPathStr
+	case !canFastKey(mType):
+		return mapPathGeneric
+	case mType.Key.Size == 4 && mType.Key.CanPointer():
+		return mapPath32Ptr
+	case mType.Key.Size == 4:
+		return mapPath32
+	case mType.Key.CanPointer():
+		return mapPath64Ptr
+	default:
+		return mapPath64
+	}
+}
+
+// mapaccessPath is mapaccess2 for the key stored at keyPtr, using the
+// routines selected by path.
+func mapaccessPath(path mapPath, mType *MapType, m *Map, keyPtr unsafe.Pointer) (unsafe.Pointer, bool) {
+	switch path {
+	case mapPathStr:
+		return mapaccess2_faststr(mType, m, *(*string)(keyPtr))
+	case mapPath32, mapPath32Ptr:
+		return mapaccess2_fast32(mType, m, *(*uint32)(keyPtr))
+	case mapPath64, mapPath64Ptr:
+		return mapaccess2_fast64(mType, m, *(*uint64)(keyPtr))
+	default:
+		return mapaccess2(mType, m, keyPtr)
+	}
+}
+
+// mapassignPath is mapassign for the key stored at keyPtr, using the
+// routines selected by path.
+func mapassignPath(path mapPath, mType *MapType, m *Map, keyPtr unsafe.Pointer) unsafe.Pointer {
+	switch path {
+	case mapPathStr:
+		return mapassign_faststr(mType, m, *(*string)(keyPtr))
+	case mapPath32Ptr:
+		return mapassign_fast32ptr(mType, m, *(*unsafe.Pointer)(keyPtr))
+	case mapPath32:
+		return mapassign_fast32(mType, m, *(*uint32)(keyPtr))
+	case mapPath64Ptr:
+		return mapassign_fast64ptr(mType, m, *(*unsafe.Pointer)(keyPtr))
+	case mapPath64:
+		return mapassign_fast64(mType, m, *(*uint64)(keyPtr))
+	default:
+		return mapassign(mType, m, keyPtr)
+	}
+}
+
+// mapassignKey is mapassign for the key stored at keyPtr, dispatching to
+// the faststr, fast32 or fast64 routine wherever the compiler would.
+func mapassignKey(mType *MapType, m *Map, keyPtr unsafe.Pointer) unsafe.Pointer {
+	return mapassignPath(mapPathOf(mType), mType, m, keyPtr)
+}
+
+//go:nosplit
+func Int32MapGet(m *Map, mType *MapType, key int32) unsafe.Pointer {
+	p, _ := Int32MapTryGet(m, mType, key)
+	return p
+}
+
+//go:nosplit
+func Int32MapGetAs[K comparable, V any](m *Map, mType *MapType, key int32) V {
+	return *(*V)(Int32MapGet(m, mType, key))
+}
+
+//go:nosplit
+func Int32MapTryGet(m *Map, mType *MapType, key int32) (unsafe.Pointer, bool) {
+	checkFastKey[int32]("Int32MapTryGet", mType)
+	return mapaccessPath(mapPathOf(mType), mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+}
+
+//go:nosplit
+func Int32MapTryGetAs[K comparable, V any](m *Map, mType *MapType, key int32) (V, bool) {
+	v, ok := Int32MapTryGet(m, mType, key)
+	if !ok || v == nil {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+//go:nosplit
+func Int32MapSet(m *Map, mType *MapType, key int32, value unsafe.Pointer) {
+	checkFastKey[int32]("Int32MapSet", mType)
+	dst := mapassignPath(mapPathOf(mType), mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+	typedmemmove(mType.Elem, dst, value)
+}
+
+//go:nosplit
+func Int64MapGet(m *Map, mType *MapType, key int64) unsafe.Pointer {
+	p, _ := Int64MapTryGet(m, mType, key)
+	return p
+}
+
+//go:nosplit
+func Int64MapGetAs[K comparable, V any](m *Map, mType *MapType, key int64) V {
+	return *(*V)(Int64MapGet(m, mType, key))
+}
+
+//go:nosplit
+func Int64MapTryGet(m *Map, mType *MapType, key int64) (unsafe.Pointer, bool) {
+	checkFastKey[int64]("Int64MapTryGet", mType)
+	return mapaccessPath(mapPathOf(mType), mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+}
+
+//go:nosplit
+func Int64MapTryGetAs[K comparable, V any](m *Map, mType *MapType, key int64) (V, bool) {
+	v, ok := Int64MapTryGet(m, mType, key)
+	if !ok || v == nil {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+//go:nosplit
+func Int64MapSet(m *Map, mType *MapType, key int64, value unsafe.Pointer) {
+	checkFastKey[int64]("Int64MapSet", mType)
+	dst := mapassignPath(mapPathOf(mType), mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+	typedmemmove(mType.Elem, dst, value)
+}
+
+// FastMapGet returns a pointer to the element of key in m, or to a zero
+// value if key is not present.
+//
+// K may be any 4- or 8-byte type of the same size as the map key type.
+// The fast32/fast64 routines are used when the map type allows it, and
+// runtime.mapaccess2 otherwise (e.g. for float keys).
+func FastMapGet[K comparable](m *Map, mType *MapType, key K) unsafe.Pointer {
+	p, _ := FastMapTryGet(m, mType, key)
+	return p
+}
+
+func FastMapGetAs[K comparable, V any](m *Map, mType *MapType, key K) V {
+	return *(*V)(FastMapGet(m, mType, key))
+}
+
+// FastMapTryGet is like FastMapGet but also reports whether key is present.
+func FastMapTryGet[K comparable](m *Map, mType *MapType, key K) (unsafe.Pointer, bool) {
+	checkFastKey[K]("FastMapTryGet", mType)
+	if canFastKey(mType) {
+		if unsafe.Sizeof(key) == 4 {
+			return mapaccess2_fast32(mType, m, *(*uint32)(unsafe.Pointer(&key)))
+		}
+		return mapaccess2_fast64(mType, m, *(*uint64)(unsafe.Pointer(&key)))
+	}
+	return mapaccess2(mType, m, abi.NoEscape(unsafe.Pointer(&key)))
+}
+
+func FastMapTryGetAs[K comparable, V any](m *Map, mType *MapType, key K) (V, bool) {
+	v, ok := FastMapTryGet(m, mType, key)
+	if !ok || v == nil {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+// FastMapSet sets the element of key in m to the value at value.
+//
+// K may be any 4- or 8-byte type of the same size as the map key type.
+// Keys containing pointers go through the fast32ptr/fast64ptr routines.
+func FastMapSet[K comparable](m *Map, mType *MapType, key K, value unsafe.Pointer) {
+	checkFastKey[K]("FastMapSet", mType)
+	// The map stores key, so it must escape; runtime.mapassign hides it
+	// from escape analysis.
+	key = abi.Escape(key)
+	dst := mapassignKey(mType, m, unsafe.Pointer(&key))
+	typedmemmove(mType.Elem, dst, value)
+}
@@ -0,0 +1,125 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+)
+
+// checkFastKey panics if K is not a 4- or 8-byte type of the same size
+// as the key type of mType.
+func checkFastKey[K comparable](fn string, mType *MapType) {
+	var key K
+	keyType := ABITypeToReflectType(&mType.Type).Key()
+	if size := unsafe.Sizeof(key); size != 4 && size != 8 || size != keyType.Size() {
+		panic("gointernals." + fn + ": key type " + TypeFor[K]().String() + " does not match map key type " + keyType.String())
+	}
+}
+
+// mapPathOf returns mapPathGeneric; the fallback build has no fast paths.
+func mapPathOf(mType *MapType) mapPath {
+	return mapPathGeneric
+}
+
+// mapaccessPath is StrMapTryGet for the key stored at keyPtr.
+func mapaccessPath(_ mapPath, mType *MapType, m *Map, keyPtr unsafe.Pointer) (unsafe.Pointer, bool) {
+	return mapTryGetAt(m, mType, keyPtr)
+}
+
+// mapassignPath is mapassign for the key stored at keyPtr.
+func mapassignPath(_ mapPath, mType *MapType, m *Map, keyPtr unsafe.Pointer) unsafe.Pointer {
+	return mapassign(mType, m, keyPtr)
+}
+
+func Int32MapGet(m *Map, mType *MapType, key int32) unsafe.Pointer {
+	p, _ := mapTryGetAt(m, mType, unsafe.Pointer(&key))
+	return p
+}
+
+func Int32MapGetAs[K comparable, V any](m *Map, mType *MapType, key int32) V {
+	return *(*V)(Int32MapGet(m, mType, key))
+}
+
+func Int32MapTryGet(m *Map, mType *MapType, key int32) (unsafe.Pointer, bool) {
+	checkFastKey[int32]("Int32MapTryGet", mType)
+	return mapTryGetAt(m, mType, unsafe.Pointer(&key))
+}
+
+func Int32MapTryGetAs[K comparable, V any](m *Map, mType *MapType, key int32) (V, bool) {
+	v, ok := Int32MapTryGet(m, mType, key)
+	if !ok {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+func Int32MapSet(m *Map, mType *MapType, key int32, value unsafe.Pointer) {
+	checkFastKey[int32]("Int32MapSet", mType)
+	mapSetAt(m, mType, unsafe.Pointer(&key), value)
+}
+
+func Int64MapGet(m *Map, mType *MapType, key int64) unsafe.Pointer {
+	p, _ := mapTryGetAt(m, mType, unsafe.Pointer(&key))
+	return p
+}
+
+func Int64MapGetAs[K comparable, V any](m *Map, mType *MapType, key int64) V {
+	return *(*V)(Int64MapGet(m, mType, key))
+}
+
+func Int64MapTryGet(m *Map, mType *MapType, key int64) (unsafe.Pointer, bool) {
+	checkFastKey[int64]("Int64MapTryGet", mType)
+	return mapTryGetAt(m, mType, unsafe.Pointer(&key))
+}
+
+func Int64MapTryGetAs[K comparable, V any](m *Map, mType *MapType, key int64) (V, bool) {
+	v, ok := Int64MapTryGet(m, mType, key)
+	if !ok {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+func Int64MapSet(m *Map, mType *MapType, key int64, value unsafe.Pointer) {
+	checkFastKey[int64]("Int64MapSet", mType)
+	mapSetAt(m, mType, unsafe.Pointer(&key), value)
+}
+
+// FastMapGet returns a pointer to the element of key in m, or to a zero
+// value if key is not present.
+//
+// K may be any 4- or 8-byte type of the same size as the map key type.
+// In the fallback build the pointer is to a copy of the element.
+func FastMapGet[K comparable](m *Map, mType *MapType, key K) unsafe.Pointer {
+	p, _ := FastMapTryGet(m, mType, key)
+	return p
+}
+
+func FastMapGetAs[K comparable, V any](m *Map, mType *MapType, key K) V {
+	return *(*V)(FastMapGet(m, mType, key))
+}
+
+// FastMapTryGet is like FastMapGet but also reports whether key is present.
+func FastMapTryGet[K comparable](m *Map, mType *MapType, key K) (unsafe.Pointer, bool) {
+	checkFastKey[K]("FastMapTryGet", mType)
+	return mapTryGetAt(m, mType, unsafe.Pointer(&key))
+}
+
+func FastMapTryGetAs[K comparable, V any](m *Map, mType *MapType, key K) (V, bool) {
+	v, ok := FastMapTryGet(m, mType, key)
+	if !ok {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+// FastMapSet sets the element of key in m to the value at value.
+//
+// K may be any 4- or 8-byte type of the same size as the map key type.
+func FastMapSet[K comparable](m *Map, mType *MapType, key K, value unsafe.Pointer) {
+	checkFastKey[K]("FastMapSet", mType)
+	mapSetAt(m, mType, unsafe.Pointer(&key), value)
+}
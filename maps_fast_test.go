@@ -0,0 +1,183 @@
+package gointernals
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"unsafe"
+)
+
+// fastMapSetLocalKey stores a pointer to a local of its own frame as a key.
+//
+//go:noinline
+func fastMapSetLocalKey(mtable *Map, mtype *MapType, x int) {
+	k, v := x, 1
+	FastMapSet(mtable, mtype, &k, unsafe.Pointer(&v))
+}
+
+// clobberStack overwrites the stack below its caller, where the frames of
+// returned calls were.
+//
+//go:noinline
+func clobberStack() {
+	var buf [16 << 10]byte
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	runtime.KeepAlive(&buf)
+}
+
+func TestInt32Map(t *testing.T) {
+	m := map[int32]string{1: "a"}
+	mtable, mtype := MapUnpack(m)
+
+	if got := Int32MapGetAs[int32, string](mtable, mtype, 1); got != "a" {
+		t.Errorf("Int32MapGetAs(1) = %q, want a", got)
+	}
+	if _, ok := Int32MapTryGetAs[int32, string](mtable, mtype, 2); ok {
+		t.Error("Int32MapTryGetAs(2) reported ok")
+	}
+	v := "b"
+	Int32MapSet(mtable, mtype, -2, unsafe.Pointer(&v))
+	if m[-2] != "b" {
+		t.Errorf("m[-2] = %q, want b", m[-2])
+	}
+}
+
+func TestInt64Map(t *testing.T) {
+	m := make(map[uint64]int)
+	mtable, mtype := MapUnpack(m)
+
+	for i := range int64(100) {
+		Int64MapSet(mtable, mtype, i, unsafe.Pointer(&i))
+	}
+	if len(m) != 100 || m[42] != 42 {
+		t.Fatalf("len = %d, m[42] = %d, want 100, 42", len(m), m[42])
+	}
+	if got, ok := Int64MapTryGetAs[uint64, int](mtable, mtype, 99); !ok || got != 99 {
+		t.Errorf("Int64MapTryGetAs(99) = %d, %v, want 99, true", got, ok)
+	}
+	if got := *(*int)(Int64MapGet(mtable, mtype, 100)); got != 0 {
+		t.Errorf("Int64MapGet(100) = %d, want 0", got)
+	}
+}
+
+func TestIntMapIndirectElem(t *testing.T) {
+	type big [200]byte
+	m32 := map[int32]big{}
+	m64 := map[int64]big{}
+	t32, mt32 := MapUnpack(m32)
+	t64, mt64 := MapUnpack(m64)
+
+	for i := range int64(50) {
+		v := big{0: byte(i), 199: byte(i + 1)}
+		Int32MapSet(t32, mt32, int32(i), unsafe.Pointer(&v))
+		Int64MapSet(t64, mt64, i, unsafe.Pointer(&v))
+	}
+	for i := range int64(50) {
+		want := big{0: byte(i), 199: byte(i + 1)}
+		if m32[int32(i)] != want || m64[i] != want {
+			t.Fatalf("entry %d was not stored intact", i)
+		}
+		if got, ok := Int32MapTryGetAs[int32, big](t32, mt32, int32(i)); !ok || got != want {
+			t.Fatalf("Int32MapTryGetAs(%d) = %v, %v", i, got[0], ok)
+		}
+		if got := Int64MapGetAs[int64, big](t64, mt64, i); got != want {
+			t.Fatalf("Int64MapGetAs(%d)[0] = %d, want %d", i, got[0], want[0])
+		}
+	}
+}
+
+func TestIntMapKeySizeMismatch(t *testing.T) {
+	mtable, mtype := MapUnpack(map[string]int{"a": 1})
+	v := 1
+	for name, fn := range map[string]func(){
+		"Int32MapTryGet": func() { Int32MapTryGet(mtable, mtype, 1) },
+		"Int32MapSet":    func() { Int32MapSet(mtable, mtype, 1, unsafe.Pointer(&v)) },
+		"Int64MapTryGet": func() { Int64MapTryGet(mtable, mtype, 1) },
+		"Int64MapSet":    func() { Int64MapSet(mtable, mtype, 1, unsafe.Pointer(&v)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on a string-keyed map did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+func TestFastMap(t *testing.T) {
+	type id uint32
+	t.Run("named 4-byte key", func(t *testing.T) {
+		m := map[id]string{7: "x"}
+		mtable, mtype := MapUnpack(m)
+		if got := FastMapGetAs[id, string](mtable, mtype, 7); got != "x" {
+			t.Errorf("FastMapGetAs(7) = %q, want x", got)
+		}
+		v := "y"
+		FastMapSet(mtable, mtype, id(8), unsafe.Pointer(&v))
+		if m[8] != "y" {
+			t.Errorf("m[8] = %q, want y", m[8])
+		}
+	})
+
+	t.Run("pointer key", func(t *testing.T) {
+		k1, k2 := new(int), new(int)
+		m := map[*int]int{k1: 1}
+		mtable, mtype := MapUnpack(m)
+		v := 2
+		FastMapSet(mtable, mtype, k2, unsafe.Pointer(&v))
+		if m[k2] != 2 {
+			t.Errorf("m[k2] = %d, want 2", m[k2])
+		}
+		if got, ok := FastMapTryGetAs[*int, int](mtable, mtype, k1); !ok || got != 1 {
+			t.Errorf("FastMapTryGetAs(k1) = %d, %v, want 1, true", got, ok)
+		}
+	})
+
+	t.Run("local pointer key", func(t *testing.T) {
+		m := make(map[*int]int)
+		mtable, mtype := MapUnpack(m)
+		fastMapSetLocalKey(mtable, mtype, 42)
+		clobberStack()
+		runtime.GC()
+		for k, v := range m {
+			if *k != 42 || v != 1 {
+				t.Errorf("m = {%d: %d}, want {42: 1}", *k, v)
+			}
+		}
+	})
+
+	t.Run("float key", func(t *testing.T) {
+		// Floats are not memory-comparable: -0 == +0.
+		m := map[float64]int{0: 1}
+		mtable, mtype := MapUnpack(m)
+		if got, ok := FastMapTryGetAs[float64, int](mtable, mtype, math.Copysign(0, -1)); !ok || got != 1 {
+			t.Errorf("FastMapTryGetAs(-0) = %d, %v, want 1, true", got, ok)
+		}
+	})
+
+	t.Run("large elem", func(t *testing.T) {
+		type big [200]byte
+		m := make(map[int64]big)
+		mtable, mtype := MapUnpack(m)
+		var v big
+		v[199] = 1
+		FastMapSet(mtable, mtype, int64(3), unsafe.Pointer(&v))
+		if got := FastMapGetAs[int64, big](mtable, mtype, 3); got != v {
+			t.Errorf("FastMapGetAs(3)[199] = %d, want 1", got[199])
+		}
+	})
+
+	t.Run("size mismatch", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("FastMapGet with mismatched key size did not panic")
+			}
+		}()
+		mtable, mtype := MapUnpack(map[int64]int{})
+		FastMapGet(mtable, mtype, int32(1))
+	})
+}
@@ -0,0 +1,66 @@
+package gointernals
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestStrMapGetOrInsert(t *testing.T) {
+	m := map[string][]int{"a": {1}}
+	mtable, mtype := MapUnpack(m)
+
+	calls := 0
+	init := func(elem unsafe.Pointer) {
+		calls++
+		*(*[]int)(elem) = []int{2}
+	}
+
+	elem, inserted := StrMapGetOrInsert(mtable, mtype, "a", init)
+	if inserted || calls != 0 || (*(*[]int)(elem))[0] != 1 {
+		t.Errorf("hit: inserted = %v, calls = %d, elem = %v", inserted, calls, *(*[]int)(elem))
+	}
+
+	elem, inserted = StrMapGetOrInsert(mtable, mtype, "b", init)
+	if !inserted || calls != 1 || (*(*[]int)(elem))[0] != 2 {
+		t.Errorf("miss: inserted = %v, calls = %d, elem = %v", inserted, calls, *(*[]int)(elem))
+	}
+	if got := m["b"]; len(got) != 1 || got[0] != 2 {
+		t.Errorf(`m["b"] = %v, want [2]`, got)
+	}
+}
+
+func TestStrMapGetOrInsertAs(t *testing.T) {
+	m := map[string]int{}
+	mtable, mtype := MapUnpack(m)
+
+	calls := 0
+	init := func() int {
+		calls++
+		return 42
+	}
+	for i := range 3 {
+		v, inserted := StrMapGetOrInsertAs[string](mtable, mtype, "x", init)
+		if v != 42 || inserted != (i == 0) {
+			t.Errorf("call %d: got (%d, %v)", i, v, inserted)
+		}
+	}
+	if calls != 1 || m["x"] != 42 || len(m) != 1 {
+		t.Errorf("calls = %d, m = %v", calls, m)
+	}
+}
+
+func TestStrMapGetOrInsertPanickingInit(t *testing.T) {
+	m := map[string]int{"a": 1}
+	mtable, mtype := MapUnpack(m)
+	func() {
+		defer func() {
+			if r := recover(); r != "init" {
+				t.Errorf("recovered %v, want the panic of init", r)
+			}
+		}()
+		StrMapGetOrInsertAs[string](mtable, mtype, "b", func() int { panic("init") })
+	}()
+	if _, ok := m["b"]; ok || len(m) != 1 {
+		t.Errorf("m = %v after init panicked, want map[a:1]", m)
+	}
+}
@@ -0,0 +1,110 @@
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// mapPath selects the runtime map routines used for a map type.
+type mapPath uint8
+
+const (
+	mapPathGeneric mapPath = iota
+	mapPathStr
+	mapPath32
+	mapPath32Ptr
+	mapPath64
+	mapPath64Ptr
+)
+
+// MapHandle is a typed handle to a map[K]V that caches its unpacked
+// Map and MapType, and the runtime routines to use for its key type.
+//
+// The zero MapHandle is a handle to a nil map.
+type MapHandle[K comparable, V any] struct {
+	m     *Map
+	mType *MapType
+	path  mapPath
+}
+
+// NewMapHandle returns a handle to m. Writes through the handle are
+// visible in m and vice versa.
+func NewMapHandle[K comparable, V any](m map[K]V) MapHandle[K, V] {
+	mtable, mType := MapUnpack(m)
+	return MapHandle[K, V]{m: mtable, mType: mType, path: mapPathOf(mType)}
+}
+
+// typ returns the map type of h. It is not cached in the zero MapHandle.
+func (h MapHandle[K, V]) typ() *MapType {
+	if h.mType == nil {
+		_, mType := MapUnpack[K, V](nil)
+		return mType
+	}
+	return h.mType
+}
+
+// Map returns the map h refers to.
+func (h MapHandle[K, V]) Map() map[K]V {
+	return *(*map[K]V)(unsafe.Pointer(&h.m))
+}
+
+// Get returns the element of key, or the zero value if key is not present.
+func (h MapHandle[K, V]) Get(key K) V {
+	v, _ := h.TryGet(key)
+	return v
+}
+
+// TryGet is like Get but also reports whether key is present.
+func (h MapHandle[K, V]) TryGet(key K) (V, bool) {
+	p, ok := mapaccessPath(h.path, h.typ(), h.m, abi.NoEscape(unsafe.Pointer(&key)))
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return *(*V)(p), true
+}
+
+// Set sets the element of key to value. It panics if the map is nil.
+func (h MapHandle[K, V]) Set(key K, value V) {
+	// The map stores key, so it must escape; runtime.mapassign hides it
+	// from escape analysis.
+	key = abi.Escape(key)
+	*(*V)(mapassignPath(h.path, h.typ(), h.m, unsafe.Pointer(&key))) = value
+}
+
+// Delete deletes key. It is a no-op if the map is nil or key is not present.
+func (h MapHandle[K, V]) Delete(key K) {
+	if h.path == mapPathStr {
+		StrMapDelete(h.m, h.typ(), *(*string)(unsafe.Pointer(&key)))
+		return
+	}
+	MapDelete(h.m, h.typ(), abi.NoEscape(unsafe.Pointer(&key)))
+}
+
+// Clear deletes all entries.
+func (h MapHandle[K, V]) Clear() {
+	MapClear(h.m, h.typ())
+}
+
+// Clone returns a shallow copy of the map.
+func (h MapHandle[K, V]) Clone() map[K]V {
+	if h.m == nil {
+		// maps.clone does not accept a nil map.
+		return nil
+	}
+	return MapCloneAs[K, V](h.m, h.typ())
+}
+
+// Range calls yield for each entry until yield returns false.
+// It can be used as a range-over-func iterator.
+func (h MapHandle[K, V]) Range(yield func(K, V) bool) {
+	MapRange(h.m, h.typ(), func(key, elem unsafe.Pointer) bool {
+		return yield(*(*K)(key), *(*V)(elem))
+	})
+}
+
+// Len returns the number of entries.
+func (h MapHandle[K, V]) Len() int {
+	return len(h.Map())
+}
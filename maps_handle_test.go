@@ -0,0 +1,136 @@
+package gointernals
+
+import (
+	"maps"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func testMapHandle[K comparable](t *testing.T, keyOf func(int) K) {
+	t.Helper()
+	m := map[K]int{}
+	h := NewMapHandle(m)
+
+	for i := range 100 {
+		h.Set(keyOf(i), i)
+	}
+	if h.Len() != 100 || len(m) != 100 {
+		t.Fatalf("Len() = %d, len(m) = %d, want 100", h.Len(), len(m))
+	}
+	for i := range 100 {
+		if v, ok := h.TryGet(keyOf(i)); !ok || v != i || m[keyOf(i)] != i {
+			t.Fatalf("TryGet(%v) = %d, %v, want %d, true", keyOf(i), v, ok, i)
+		}
+	}
+	if v, ok := h.TryGet(keyOf(1000)); ok || v != 0 {
+		t.Errorf("TryGet(missing) = %d, %v, want 0, false", v, ok)
+	}
+
+	clone := h.Clone()
+	if !maps.Equal(clone, m) {
+		t.Errorf("Clone() differs from the map")
+	}
+
+	for i := range 50 {
+		h.Delete(keyOf(i))
+	}
+	sum, n := 0, 0
+	for _, v := range h.Range {
+		sum += v
+		n++
+	}
+	if n != 50 || sum != (50+99)*50/2 {
+		t.Errorf("Range visited %d entries summing to %d after delete", n, sum)
+	}
+
+	h.Clear()
+	if h.Len() != 0 || len(clone) != 100 {
+		t.Errorf("after Clear: Len() = %d, len(clone) = %d", h.Len(), len(clone))
+	}
+}
+
+func TestMapHandle(t *testing.T) {
+	type point struct{ X, Y int }
+	ptrs := make([]*int, 1001)
+	for i := range ptrs {
+		ptrs[i] = new(int)
+	}
+
+	t.Run("string", func(t *testing.T) { testMapHandle(t, strconv.Itoa) })
+	t.Run("int32", func(t *testing.T) { testMapHandle(t, func(i int) int32 { return int32(i) }) })
+	t.Run("int64", func(t *testing.T) { testMapHandle(t, func(i int) int64 { return int64(i) }) })
+	t.Run("pointer", func(t *testing.T) { testMapHandle(t, func(i int) *int { return ptrs[i] }) })
+	t.Run("struct", func(t *testing.T) { testMapHandle(t, func(i int) point { return point{i, -i} }) })
+	t.Run("float64", func(t *testing.T) { testMapHandle(t, func(i int) float64 { return float64(i) + 0.5 }) })
+}
+
+// handleSetLocalKeys stores keys that refer to locals of its own frame.
+//
+//go:noinline
+func handleSetLocalKeys(hp MapHandle[*int, int], hs MapHandle[string, int], x int, s string) {
+	k := x
+	hp.Set(&k, 1)
+	b := []byte(s)
+	hs.Set(string(b), 1)
+}
+
+func TestMapHandleLocalKeys(t *testing.T) {
+	mp, ms := map[*int]int{}, map[string]int{}
+	handleSetLocalKeys(NewMapHandle(mp), NewMapHandle(ms), 42, "key")
+	clobberStack()
+	runtime.GC()
+	for k := range mp {
+		if *k != 42 {
+			t.Errorf("pointer key = &%d, want &42", *k)
+		}
+	}
+	for k := range ms {
+		if k != "key" {
+			t.Errorf("string key = %q, want key", k)
+		}
+	}
+}
+
+func TestMapHandleNilMap(t *testing.T) {
+	var m map[string]int
+	h := NewMapHandle(m)
+	if h.Get("a") != 0 || h.Len() != 0 || h.Map() != nil {
+		t.Errorf("nil map handle is not empty")
+	}
+	h.Delete("a")
+	h.Range(func(string, int) bool {
+		t.Error("Range over nil map called yield")
+		return true
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Set on nil map did not panic")
+		}
+	}()
+	h.Set("a", 1)
+}
+
+func TestMapHandleZero(t *testing.T) {
+	var h MapHandle[string, int]
+	if h.Get("a") != 0 || h.Len() != 0 || h.Map() != nil || h.Clone() != nil {
+		t.Errorf("zero handle is not a nil map")
+	}
+	if _, ok := h.TryGet("a"); ok {
+		t.Error("TryGet on zero handle reported ok")
+	}
+	h.Delete("a")
+	h.Clear()
+	h.Range(func(string, int) bool {
+		t.Error("Range over zero handle called yield")
+		return true
+	})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Set on zero handle did not panic")
+		}
+	}()
+	h.Set("a", 1)
+}
@@ -0,0 +1,86 @@
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// ErrUnhashableKey is returned by MapTryAssign and ReflectMapTryAssign
+// when a map key holds, in an interface, a value of a type the runtime
+// cannot hash.
+type ErrUnhashableKey struct {
+	Type reflect.Type // the unhashable dynamic type
+}
+
+func (e *ErrUnhashableKey) Error() string {
+	return "gointernals: unhashable map key type " + e.Type.String()
+}
+
+// checkHashable returns an *ErrUnhashableKey if the value of type t at p
+// cannot be hashed.
+func checkHashable(t reflect.Type, p unsafe.Pointer) error {
+	if !t.Comparable() {
+		return &ErrUnhashableKey{Type: t}
+	}
+	if !mayHoldInterface(t) {
+		return nil
+	}
+	if bad := unhashableType(reflect.NewAt(t, p).Elem()); bad != nil {
+		return &ErrUnhashableKey{Type: bad}
+	}
+	return nil
+}
+
+// unhashableType returns the first dynamic type held in an interface
+// within v that is not comparable, or nil if there is none.
+// Like the runtime's typehash, it skips blank struct fields.
+func unhashableType(v reflect.Value) reflect.Type {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		e := v.Elem()
+		if !e.Type().Comparable() {
+			return e.Type()
+		}
+		if mayHoldInterface(e.Type()) {
+			return unhashableType(e)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			if t.Field(i).Name == "_" || !mayHoldInterface(t.Field(i).Type) {
+				continue
+			}
+			if bad := unhashableType(v.Field(i)); bad != nil {
+				return bad
+			}
+		}
+	case reflect.Array:
+		for i := range v.Len() {
+			if bad := unhashableType(v.Index(i)); bad != nil {
+				return bad
+			}
+		}
+	}
+	return nil
+}
+
+// mayHoldInterface reports whether values of type t contain an interface,
+// which is when the runtime sets the HashMightPanic flag of a map type.
+func mayHoldInterface(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && mayHoldInterface(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if mayHoldInterface(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package gointernals
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestMapTryAssign(t *testing.T) {
+	type withIface struct {
+		_ any // blank fields are not hashed
+		X any
+	}
+	m := map[withIface]int{}
+	mtable, mtype := MapUnpack(m)
+
+	good := withIface{X: [2]any{1, "s"}}
+	elem, err := MapTryAssign(mtable, mtype, unsafe.Pointer(&good))
+	if err != nil {
+		t.Fatalf("MapTryAssign(good) error: %v", err)
+	}
+	*(*int)(elem) = 1
+	if m[good] != 1 {
+		t.Errorf("m[good] = %d, want 1", m[good])
+	}
+
+	bad := withIface{X: [2]any{1, map[string]int{}}}
+	_, err = MapTryAssign(mtable, mtype, unsafe.Pointer(&bad))
+	var unhashable *ErrUnhashableKey
+	if !errors.As(err, &unhashable) || unhashable.Type != reflect.TypeFor[map[string]int]() {
+		t.Fatalf("MapTryAssign(bad) error = %v, want ErrUnhashableKey for map[string]int", err)
+	}
+	if len(m) != 1 {
+		t.Errorf("len(m) = %d after failed assign, want 1", len(m))
+	}
+}
+
+func TestReflectMapTryAssign(t *testing.T) {
+	m := map[any]string{}
+	mv := reflect.ValueOf(m)
+
+	ev, err := ReflectMapTryAssign(mv, 1)
+	if err != nil {
+		t.Fatalf("ReflectMapTryAssign(1) error: %v", err)
+	}
+	ev.SetString("one")
+	if m[1] != "one" {
+		t.Errorf("m[1] = %q, want one", m[1])
+	}
+
+	_, err = ReflectMapTryAssign(mv, []int{1})
+	var unhashable *ErrUnhashableKey
+	if !errors.As(err, &unhashable) || unhashable.Type != reflect.TypeFor[[]int]() {
+		t.Errorf("ReflectMapTryAssign([]int) error = %v, want ErrUnhashableKey for []int", err)
+	}
+
+	_, err = ReflectMapTryAssign(reflect.ValueOf(map[string]int{}), 1.5)
+	if err == nil || errors.As(err, &unhashable) {
+		t.Errorf("ReflectMapTryAssign(1.5) error = %v, want key type error", err)
+	}
+}
+
+func TestMapTypeFlags(t *testing.T) {
+	type big [MapMaxKeyBytes + 1]byte
+	type withFloat struct {
+		A int
+		B float64
+	}
+	for _, tt := range []struct {
+		m                                             any
+		indirectKey, indirectElem, update, mightPanic bool
+	}{
+		{map[int]int{}, false, false, false, false},
+		{map[string]big{}, false, true, true, false},
+		{map[big]int{}, true, false, false, false},
+		{map[withFloat]int{}, false, false, true, false},
+		{map[[2]any]int{}, false, false, true, true},
+		{map[*int]int{}, false, false, false, false},
+	} {
+		mType := (*MapType)(unsafe.Pointer(ReflectTypeToABIType(reflect.TypeOf(tt.m))))
+		if got := mType.IndirectKey(); got != tt.indirectKey {
+			t.Errorf("%T: IndirectKey = %v, want %v", tt.m, got, tt.indirectKey)
+		}
+		if got := mType.IndirectElem(); got != tt.indirectElem {
+			t.Errorf("%T: IndirectElem = %v, want %v", tt.m, got, tt.indirectElem)
+		}
+		if got := mType.NeedKeyUpdate(); got != tt.update {
+			t.Errorf("%T: NeedKeyUpdate = %v, want %v", tt.m, got, tt.update)
+		}
+		if got := mType.HashMightPanic(); got != tt.mightPanic {
+			t.Errorf("%T: HashMightPanic = %v, want %v", tt.m, got, tt.mightPanic)
+		}
+	}
+}
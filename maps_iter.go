@@ -0,0 +1,81 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+)
+
+//go:linkname mapiterinit runtime.mapiterinit
+//go:noescape
+func mapiterinit(t *MapType, m *Map, it *hiter)
+
+//go:linkname mapiternext runtime.mapiternext
+//go:noescape
+func mapiternext(it *hiter)
+
+// MapIter is an iterator for ranging over a map, in the same order and with
+// the same semantics as a for range statement. It is resumable: the position
+// is kept between calls to Next.
+//
+// The runtime iterator dereferences IndirectKey and IndirectElem slots,
+// so Key and Elem always point to the key and element themselves.
+type MapIter struct {
+	hiter
+	m       *Map
+	mType   *MapType
+	started bool
+}
+
+// NewMapIter returns an iterator over the map m of type mType.
+func NewMapIter(m *Map, mType *MapType) *MapIter {
+	return &MapIter{m: m, mType: mType}
+}
+
+// Reset modifies it to iterate over the map m of type mType.
+func (it *MapIter) Reset(m *Map, mType *MapType) {
+	*it = MapIter{m: m, mType: mType}
+}
+
+// Next advances the iterator and reports whether there is another entry.
+// It returns false when the iterator is exhausted.
+func (it *MapIter) Next() bool {
+	if !it.started {
+		it.started = true
+		mapiterinit(it.mType, it.m, &it.hiter)
+	} else {
+		if it.key == nil {
+			panic("gointernals.MapIter.Next called on exhausted iterator")
+		}
+		mapiternext(&it.hiter)
+	}
+	return it.key != nil
+}
+
+// Key returns a pointer to the key of the current entry.
+// The key must not be modified.
+func (it *MapIter) Key() unsafe.Pointer {
+	if !it.started || it.key == nil {
+		panic("gointernals.MapIter.Key called before Next or after exhaustion")
+	}
+	return it.key
+}
+
+// Elem returns a pointer to the element of the current entry.
+func (it *MapIter) Elem() unsafe.Pointer {
+	if !it.started || it.key == nil {
+		panic("gointernals.MapIter.Elem called before Next or after exhaustion")
+	}
+	return it.elem
+}
+
+// MapRange calls fn for each entry of the map m of type mType, with pointers
+// to its key and element, until fn returns false.
+func MapRange(m *Map, mType *MapType, fn func(key, elem unsafe.Pointer) bool) {
+	var it hiter
+	for mapiterinit(mType, m, &it); it.key != nil; mapiternext(&it) {
+		if !fn(it.key, it.elem) {
+			return
+		}
+	}
+}
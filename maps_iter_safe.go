@@ -0,0 +1,67 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// MapIter is an iterator for ranging over a map, in the same order and with
+// the same semantics as a for range statement. It is resumable: the position
+// is kept between calls to Next.
+//
+// In the fallback build Key and Elem point to copies of the key and element.
+type MapIter struct {
+	iter      *reflect.MapIter
+	key, elem unsafe.Pointer
+}
+
+// NewMapIter returns an iterator over the map m of type mType.
+func NewMapIter(m *Map, mType *MapType) *MapIter {
+	return &MapIter{iter: mapValue(m, mType).MapRange()}
+}
+
+// Reset modifies it to iterate over the map m of type mType.
+func (it *MapIter) Reset(m *Map, mType *MapType) {
+	*it = MapIter{iter: mapValue(m, mType).MapRange()}
+}
+
+// Next advances the iterator and reports whether there is another entry.
+// It returns false when the iterator is exhausted.
+func (it *MapIter) Next() bool {
+	if !it.iter.Next() {
+		it.key, it.elem = nil, nil
+		return false
+	}
+	k, v := it.iter.Key(), it.iter.Value()
+	it.key, it.elem = valuePtr(k, k.Type()), valuePtr(v, v.Type())
+	return true
+}
+
+// Key returns a pointer to the key of the current entry.
+// The key must not be modified.
+func (it *MapIter) Key() unsafe.Pointer {
+	if it.key == nil {
+		panic("gointernals.MapIter.Key called before Next or after exhaustion")
+	}
+	return it.key
+}
+
+// Elem returns a pointer to the element of the current entry.
+func (it *MapIter) Elem() unsafe.Pointer {
+	if it.key == nil {
+		panic("gointernals.MapIter.Elem called before Next or after exhaustion")
+	}
+	return it.elem
+}
+
+// MapRange calls fn for each entry of the map m of type mType, with pointers
+// to its key and element, until fn returns false.
+func MapRange(m *Map, mType *MapType, fn func(key, elem unsafe.Pointer) bool) {
+	for it := NewMapIter(m, mType); it.Next(); {
+		if !fn(it.key, it.elem) {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package gointernals
+
+import (
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+func TestMapRange(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 100 {
+		m[strconv.Itoa(i)] = i
+	}
+	mtable, mtype := MapUnpack(m)
+
+	seen := make(map[string]int)
+	MapRange(mtable, mtype, func(key, elem unsafe.Pointer) bool {
+		seen[*(*string)(key)] = *(*int)(elem)
+		return true
+	})
+	if len(seen) != len(m) {
+		t.Fatalf("visited %d entries, want %d", len(seen), len(m))
+	}
+	for k, v := range m {
+		if seen[k] != v {
+			t.Errorf("seen[%q] = %d, want %d", k, seen[k], v)
+		}
+	}
+
+	n := 0
+	MapRange(mtable, mtype, func(key, elem unsafe.Pointer) bool {
+		n++
+		return n < 10
+	})
+	if n != 10 {
+		t.Errorf("visited %d entries after stopping, want 10", n)
+	}
+}
+
+func TestMapRangeIndirect(t *testing.T) {
+	// Keys and elems larger than 128 bytes are stored indirectly.
+	type big [200]byte
+	m := make(map[big]big)
+	for i := range 20 {
+		var k, v big
+		k[0], k[199] = byte(i), 1
+		v[0], v[199] = byte(i), 2
+		m[k] = v
+	}
+	mtable, mtype := MapUnpack(m)
+
+	n := 0
+	MapRange(mtable, mtype, func(key, elem unsafe.Pointer) bool {
+		k, v := *(*big)(key), *(*big)(elem)
+		if k[199] != 1 || v[199] != 2 || k[0] != v[0] || m[k] != v {
+			t.Errorf("entry %d: key %d/%d, elem %d/%d", n, k[0], k[199], v[0], v[199])
+		}
+		n++
+		return true
+	})
+	if n != len(m) {
+		t.Errorf("visited %d entries, want %d", n, len(m))
+	}
+}
+
+func TestMapIter(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b", 3: "c", 4: "d"}
+	mtable, mtype := MapUnpack(m)
+
+	it := NewMapIter(mtable, mtype)
+	seen := make(map[int]string)
+	// Stop halfway and resume with the same iterator.
+	for range 2 {
+		if !it.Next() {
+			t.Fatal("Next() = false before the end")
+		}
+		seen[*(*int)(it.Key())] = *(*string)(it.Elem())
+	}
+	for it.Next() {
+		seen[*(*int)(it.Key())] = *(*string)(it.Elem())
+	}
+	if len(seen) != len(m) {
+		t.Fatalf("visited %d entries, want %d", len(seen), len(m))
+	}
+	for k, v := range m {
+		if seen[k] != v {
+			t.Errorf("seen[%d] = %q, want %q", k, seen[k], v)
+		}
+	}
+
+	var empty map[int]string
+	it.Reset(MapUnpack(empty))
+	if it.Next() {
+		t.Error("Next() = true for a nil map")
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// MapKeys returns a new slice of the keys of m, in iteration order.
+func MapKeys(m *Map, mType *MapType) *Slice {
+	return mapCollect(m, mType, mType.Key, func(key, _ unsafe.Pointer) unsafe.Pointer { return key })
+}
+
+// MapValues returns a new slice of the elements of m, in iteration order.
+func MapValues(m *Map, mType *MapType) *Slice {
+	return mapCollect(m, mType, mType.Elem, func(_, elem unsafe.Pointer) unsafe.Pointer { return elem })
+}
+
+// mapCollect copies the value picked by pick from each entry of m into a
+// slice of typ presized to the length of m.
+func mapCollect(m *Map, mType *MapType, typ *abi.Type, pick func(key, elem unsafe.Pointer) unsafe.Pointer) *Slice {
+	n := mapLen(m)
+	s := &Slice{ptr: makeslice(typ, n, n), len: n, cap: n}
+	i := 0
+	MapRange(m, mType, func(key, elem unsafe.Pointer) bool {
+		if i == n {
+			return false
+		}
+		typedmemmove(typ, unsafe.Add(s.ptr, uintptr(i)*typ.Size), pick(key, elem))
+		i++
+		return true
+	})
+	s.len = i
+	return s
+}
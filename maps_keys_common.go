@@ -0,0 +1,23 @@
+package gointernals
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MapKeysAs returns a new slice of the keys of m, in iteration order.
+func MapKeysAs[K comparable](m *Map, mType *MapType) []K {
+	return SlicePack[K](MapKeys(m, mType))
+}
+
+// MapValuesAs returns a new slice of the elements of m, in iteration order.
+func MapValuesAs[V any](m *Map, mType *MapType) []V {
+	return SlicePack[V](MapValues(m, mType))
+}
+
+// MapSortedKeysAs returns a new slice of the keys of m in increasing order.
+func MapSortedKeysAs[K cmp.Ordered](m *Map, mType *MapType) []K {
+	keys := MapKeysAs[K](m, mType)
+	slices.Sort(keys)
+	return keys
+}
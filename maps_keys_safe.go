@@ -0,0 +1,35 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// MapKeys returns a new slice of the keys of m, in iteration order.
+func MapKeys(m *Map, mType *MapType) *Slice {
+	v := mapValue(m, mType)
+	return mapCollect(v, v.Type().Key(), (*reflect.MapIter).Key)
+}
+
+// MapValues returns a new slice of the elements of m, in iteration order.
+func MapValues(m *Map, mType *MapType) *Slice {
+	v := mapValue(m, mType)
+	return mapCollect(v, v.Type().Elem(), (*reflect.MapIter).Value)
+}
+
+// mapCollect copies the value picked by pick from each entry of the map v
+// into a slice of typ presized to the length of v.
+func mapCollect(v reflect.Value, typ reflect.Type, pick func(*reflect.MapIter) reflect.Value) *Slice {
+	n := v.Len()
+	s := new(Slice)
+	sv := reflect.NewAt(reflect.SliceOf(typ), unsafe.Pointer(s)).Elem()
+	sv.Set(reflect.MakeSlice(sv.Type(), n, n))
+	i := 0
+	for iter := v.MapRange(); iter.Next() && i < n; i++ {
+		sv.Index(i).Set(pick(iter))
+	}
+	s.len = i
+	return s
+}
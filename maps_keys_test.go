@@ -0,0 +1,42 @@
+package gointernals
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMapKeysValues(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 100 {
+		m[strconv.Itoa(i)] = i
+	}
+	mtable, mtype := MapUnpack(m)
+
+	keys := MapKeysAs[string](mtable, mtype)
+	values := MapValuesAs[int](mtable, mtype)
+	if len(keys) != len(m) || len(values) != len(m) {
+		t.Fatalf("len(keys), len(values) = %d, %d, want %d", len(keys), len(values), len(m))
+	}
+	slices.Sort(values)
+	for i, v := range values {
+		if v != i {
+			t.Fatalf("sorted values[%d] = %d, want %d", i, v, i)
+		}
+	}
+	for _, k := range keys {
+		if _, ok := m[k]; !ok {
+			t.Errorf("unexpected key %q", k)
+		}
+	}
+
+	ints := MapSortedKeysAs[int](MapUnpack(map[int]string{3: "c", 1: "a", 2: "b"}))
+	if !slices.Equal(ints, []int{1, 2, 3}) {
+		t.Errorf("MapSortedKeysAs = %v, want [1 2 3]", ints)
+	}
+
+	var nilMap map[string]int
+	if keys := MapKeys(MapUnpack(nilMap)); keys.Len() != 0 {
+		t.Errorf("MapKeys(nil) has %d keys", keys.Len())
+	}
+}
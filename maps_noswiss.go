@@ -0,0 +1,145 @@
+//go:build go1.24 && !go1.26 && !goexperiment.swissmap && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// Legacy map constants (GOEXPERIMENT=noswissmap).
+const (
+	// Maximum number of key/elem pairs a bucket can hold.
+	MapBucketCountBits = 3
+	MapBucketCount     = 1 << MapBucketCountBits // 8
+)
+
+// MapType is the map type descriptor of the legacy (noswissmap) layout
+// (internal/abi.OldMapType).
+type MapType struct {
+	abi.Type
+	Key    *abi.Type
+	Elem   *abi.Type
+	Bucket *abi.Type // internal type representing a hash bucket
+	// function for hashing keys (ptr to key, seed) -> hash
+	Hasher     func(unsafe.Pointer, uintptr) uintptr
+	KeySize    uint8  // size of key slot
+	ValueSize  uint8  // size of elem slot
+	BucketSize uint16 // size of bucket
+	Flags      uint32
+}
+
+// Flag values
+const (
+	MapIndirectKey = 1 << iota
+	MapIndirectElem
+	MapReflexiveKey
+	MapNeedKeyUpdate
+	MapHashMightPanic
+)
+
+func (mt *MapType) IndirectKey() bool { // store ptr to key instead of key itself
+	return mt.Flags&MapIndirectKey != 0
+}
+func (mt *MapType) IndirectElem() bool { // store ptr to elem instead of elem itself
+	return mt.Flags&MapIndirectElem != 0
+}
+func (mt *MapType) ReflexiveKey() bool { // true if k==k for all keys
+	return mt.Flags&MapReflexiveKey != 0
+}
+func (mt *MapType) NeedKeyUpdate() bool { // true if we need to update key on an overwrite
+	return mt.Flags&MapNeedKeyUpdate != 0
+}
+func (mt *MapType) HashMightPanic() bool { // true if hash function might panic
+	return mt.Flags&MapHashMightPanic != 0
+}
+
+// Hmap.Flags values used here.
+const (
+	sameSizeGrow = 8 // the current map growth is to a new map of the same size
+)
+
+// Map is the runtime map header. With the legacy layout it is an Hmap.
+type Map = Hmap
+
+// hiter is the iterator argument of runtime.mapiterinit and runtime.mapiternext,
+// the legacy map iterator itself.
+type hiter struct {
+	key         unsafe.Pointer // Must be in first position.  Write nil to indicate iteration end (see cmd/compile/internal/walk/range.go).
+	elem        unsafe.Pointer // Must be in second position (see cmd/compile/internal/walk/range.go).
+	t           *MapType
+	h           *Hmap
+	buckets     unsafe.Pointer // bucket ptr at hash_iter initialization time
+	bptr        unsafe.Pointer // current bucket
+	overflow    unsafe.Pointer // keeps overflow buckets of hmap.buckets alive
+	oldoverflow unsafe.Pointer // keeps overflow buckets of hmap.oldbuckets alive
+	startBucket uintptr        // bucket iteration started at
+	offset      uint8          // intra-bucket offset to start from during iteration (should be big enough to hold bucketCnt-1)
+	wrapped     bool           // already wrapped around from end of bucket array to beginning
+	B           uint8
+	i           uint8
+	bucket      uintptr
+	checkBucket uintptr
+	clearSeq    uint64
+}
+
+// mapLen returns the number of entries in m.
+func mapLen(m *Map) int {
+	if m == nil {
+		return 0
+	}
+	return m.Count
+}
+
+// mapFootprint returns an approximation of the memory held by the map m:
+// the header, the buckets being used and evacuated, and their overflow
+// buckets. Keys and elements stored indirectly are not counted, and the
+// overflow count of the current buckets is approximate for large maps.
+func mapFootprint(m *Map, mType *MapType) uintptr {
+	if m == nil {
+		return 0
+	}
+	n := unsafe.Sizeof(Hmap{})
+	bucketSize := uintptr(mType.BucketSize)
+	overflow := uintptr(m.Noverflow)
+	if m.Buckets != nil {
+		n += (uintptr(1) << m.B) * bucketSize
+		if m.B >= 4 {
+			// makeBucketArray preallocates 2^(B-4) overflow buckets,
+			// which are counted in Noverflow once used.
+			overflow = max(overflow, uintptr(1)<<(m.B-4))
+		}
+	}
+	if m.Oldbuckets != nil {
+		oldB := m.B
+		if m.Flags&sameSizeGrow == 0 {
+			oldB--
+		}
+		n += (uintptr(1) << oldB) * bucketSize
+		// Noverflow was reset by hashGrow; walk the overflow chains of
+		// the old buckets instead. Evacuation clears the chains of
+		// buckets whose overflow buckets are no longer held.
+		overflow += bucketChainOverflow(m.Oldbuckets, uintptr(1)<<oldB, bucketSize)
+	}
+	if m.Extra != nil {
+		n += unsafe.Sizeof(MapExtra{})
+	}
+	return n + overflow*bucketSize
+}
+
+// bucketChainOverflow returns the number of overflow buckets chained to
+// the nbuckets buckets of the bucket array at buckets.
+func bucketChainOverflow(buckets unsafe.Pointer, nbuckets, bucketSize uintptr) uintptr {
+	// The overflow pointer is the last word of a bucket.
+	overflowOf := func(b unsafe.Pointer) unsafe.Pointer {
+		return *(*unsafe.Pointer)(unsafe.Add(b, bucketSize-abi.PtrSize))
+	}
+	n := uintptr(0)
+	for i := range nbuckets {
+		for b := overflowOf(unsafe.Add(buckets, i*bucketSize)); b != nil; b = overflowOf(b) {
+			n++
+		}
+	}
+	return n
+}
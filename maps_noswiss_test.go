@@ -0,0 +1,133 @@
+//go:build go1.24 && !go1.26 && !goexperiment.swissmap && !gointernals_safe
+
+package gointernals
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+func TestHmap(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 100 {
+		m[strconv.Itoa(i)] = i
+	}
+	h, mType := MapUnpack(m)
+	if h.Count != len(m) {
+		t.Errorf("Hmap.Count = %d, want %d", h.Count, len(m))
+	}
+	if h.B == 0 || h.Buckets == nil {
+		t.Errorf("Hmap.B = %d, Buckets = %v, want a grown map", h.B, h.Buckets)
+	}
+	if mType.Bucket == nil || uintptr(mType.BucketSize) != mType.Bucket.Size {
+		t.Errorf("MapType.BucketSize = %d does not match its Bucket type", mType.BucketSize)
+	}
+
+	clone := MapCloneAs[string, int](h, mType)
+	MapClear(h, mType)
+	if len(m) != 0 || h.Count != 0 {
+		t.Errorf("len after MapClear = %d, Hmap.Count = %d", len(m), h.Count)
+	}
+	if len(clone) != 100 || clone["42"] != 42 {
+		t.Errorf("clone has %d entries, clone[42] = %d", len(clone), clone["42"])
+	}
+}
+
+func TestMapCloneIntoReusesBuckets(t *testing.T) {
+	src := make(map[int]int)
+	for i := range 1000 {
+		src[i] = i
+	}
+	dst := make(map[int]int, 2*len(src))
+	h, _ := MapUnpack(dst)
+	buckets, b := h.Buckets, h.B
+
+	MapCloneIntoAs(dst, src)
+	if h.Count != len(src) {
+		t.Errorf("Hmap.Count = %d, want %d", h.Count, len(src))
+	}
+	if h.Buckets != buckets || h.B != b {
+		t.Errorf("MapCloneInto reallocated the bucket array (B %d -> %d)", b, h.B)
+	}
+}
+
+func TestMapCompactReclaimsBuckets(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 10000 {
+		m[strconv.Itoa(i)] = i
+	}
+	for i := 10; i < 10000; i++ {
+		delete(m, strconv.Itoa(i))
+	}
+	h, mType := MapUnpack(m)
+
+	compacted, reclaimed := MapCompact(h, mType)
+	if compacted.Count != 10 || compacted.B >= h.B {
+		t.Errorf("compacted Count/B = %d/%d, want 10 and B < %d", compacted.Count, compacted.B, h.B)
+	}
+	if reclaimed <= 0 || reclaimed != int(mapFootprint(h, mType))-int(mapFootprint(compacted, mType)) {
+		t.Errorf("reclaimed = %d, want a positive footprint difference", reclaimed)
+	}
+}
+
+func TestMapFootprintGrowing(t *testing.T) {
+	_, mType := MapUnpack(map[int64]int64{})
+	bucketSize := uintptr(mType.BucketSize)
+	buckets := make([]byte, 16*bucketSize)
+	oldbuckets := make([]byte, 16*bucketSize)
+	overflow := make([]byte, bucketSize)
+	// Chain one overflow bucket to the first old bucket.
+	*(*unsafe.Pointer)(unsafe.Pointer(&oldbuckets[bucketSize-abi.PtrSize])) = unsafe.Pointer(&overflow[0])
+
+	for _, tt := range []struct {
+		name       string
+		flags      uint8
+		oldBuckets uintptr
+	}{
+		{"doubling", 0, 8},
+		{"same size", sameSizeGrow, 16},
+	} {
+		h := &Hmap{
+			Flags:      tt.flags,
+			B:          4,
+			Buckets:    unsafe.Pointer(&buckets[0]),
+			Oldbuckets: unsafe.Pointer(&oldbuckets[0]),
+		}
+		// 16 buckets, 1 preallocated overflow bucket, the old buckets
+		// and their overflow bucket.
+		want := unsafe.Sizeof(Hmap{}) + (16+1+tt.oldBuckets+1)*bucketSize
+		if got := mapFootprint(h, mType); got != want {
+			t.Errorf("%s: mapFootprint = %d, want %d", tt.name, got, want)
+		}
+	}
+	runtime.KeepAlive(overflow)
+}
+
+func TestStrMapDeleteIndirectElem(t *testing.T) {
+	m := make(map[string][300]byte)
+	for i := range 50 {
+		m[strconv.Itoa(i)] = [300]byte{byte(i)}
+	}
+	h, mType := MapUnpack(m)
+	for i := range 50 {
+		StrMapDelete(h, mType, strconv.Itoa(i))
+	}
+	if len(m) != 0 {
+		t.Errorf("len(m) = %d after deleting every key, want 0", len(m))
+	}
+
+	c := NewConcurrentStrMap[[300]byte](4)
+	for i := range 50 {
+		c.Set(strconv.Itoa(i), [300]byte{byte(i)})
+	}
+	for i := range 50 {
+		c.Delete(strconv.Itoa(i))
+	}
+	if c.Len() != 0 {
+		t.Errorf("ConcurrentStrMap.Len() = %d after deleting every key, want 0", c.Len())
+	}
+}
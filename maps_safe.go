@@ -0,0 +1,246 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// Map is an opaque handle to a runtime map.
+//
+// In the fallback build the map layout is not mirrored; a *Map is only
+// the data word of the map's interface value and is accessed through reflect.
+type Map struct {
+	_ [0]func()
+}
+
+// Maximum key or elem size to keep inline (instead of mallocing per element).
+const (
+	MapMaxKeyBytes  = 128
+	MapMaxElemBytes = 128
+)
+
+// MapType is an opaque handle to a runtime map type descriptor.
+//
+// Its flags are derived from the key and element types with the
+// compiler's rules, since the descriptor fields are not accessible.
+type MapType struct {
+	abi.Type
+}
+
+func (mt *MapType) IndirectKey() bool { // store ptr to key instead of key itself
+	return ABITypeToReflectType(&mt.Type).Key().Size() > MapMaxKeyBytes
+}
+func (mt *MapType) IndirectElem() bool { // store ptr to elem instead of elem itself
+	return ABITypeToReflectType(&mt.Type).Elem().Size() > MapMaxElemBytes
+}
+func (mt *MapType) NeedKeyUpdate() bool { // true if we need to update key on an overwrite
+	return needKeyUpdate(ABITypeToReflectType(&mt.Type).Key())
+}
+func (mt *MapType) HashMightPanic() bool { // true if hash function might panic
+	return mayHoldInterface(ABITypeToReflectType(&mt.Type).Key())
+}
+
+// needKeyUpdate reports whether keys of type t that are equal may still
+// differ in memory (e.g. +0 and -0), so an overwrite must store the new key.
+func needKeyUpdate(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Interface, reflect.String:
+		return true
+	case reflect.Array:
+		return needKeyUpdate(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			if needKeyUpdate(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type (
+	StrMapGetFunc = func(m *Map, mType *MapType, key string) unsafe.Pointer
+	StrMapSetFunc = func(m *Map, mType *MapType, key string, value unsafe.Pointer)
+)
+
+// mapValue returns the reflect.Value of the map m of type mType.
+func mapValue(m *Map, mType *MapType) reflect.Value {
+	return reflect.ValueOf(MapToAny(m, mType))
+}
+
+// mapStrKey returns key as a reflect.Value of the key type of the map v.
+func mapStrKey(v reflect.Value, key string) reflect.Value {
+	k := reflect.ValueOf(key)
+	if keyType := v.Type().Key(); keyType != k.Type() {
+		k = k.Convert(keyType)
+	}
+	return k
+}
+
+// mapKeyAt returns the key of the map v stored at p, reinterpreting the
+// memory as the map key type.
+func mapKeyAt(v reflect.Value, p unsafe.Pointer) reflect.Value {
+	return reflect.NewAt(v.Type().Key(), p).Elem()
+}
+
+// mapTryGetAt is StrMapTryGet for the key stored at keyPtr.
+func mapTryGetAt(m *Map, mType *MapType, keyPtr unsafe.Pointer) (unsafe.Pointer, bool) {
+	v := mapValue(m, mType)
+	e := v.MapIndex(mapKeyAt(v, keyPtr))
+	return valuePtr(e, v.Type().Elem()), e.IsValid()
+}
+
+// mapSetAt is StrMapSet for the key stored at keyPtr.
+func mapSetAt(m *Map, mType *MapType, keyPtr, value unsafe.Pointer) {
+	v := mapValue(m, mType)
+	v.SetMapIndex(mapKeyAt(v, keyPtr), reflect.NewAt(v.Type().Elem(), value).Elem())
+}
+
+// valuePtr returns a pointer to a copy of v, or to a new zero value of
+// type typ if v is invalid.
+func valuePtr(v reflect.Value, typ reflect.Type) unsafe.Pointer {
+	p := reflect.New(typ)
+	if v.IsValid() {
+		p.Elem().Set(v)
+	}
+	return p.UnsafePointer()
+}
+
+// StrMapGet returns a pointer to the element of key in m, or to a zero
+// value if key is not present.
+//
+// In the fallback build the pointer is to a copy of the element.
+func StrMapGet(m *Map, mType *MapType, key string) unsafe.Pointer {
+	v := mapValue(m, mType)
+	return valuePtr(v.MapIndex(mapStrKey(v, key)), v.Type().Elem())
+}
+
+func StrMapGetAs[K comparable, V any](m *Map, mType *MapType, key string) V {
+	return *(*V)(StrMapGet(m, mType, key))
+}
+
+// StrMapTryGet is like StrMapGet but also reports whether key is present.
+func StrMapTryGet(m *Map, mType *MapType, key string) (unsafe.Pointer, bool) {
+	v := mapValue(m, mType)
+	e := v.MapIndex(mapStrKey(v, key))
+	return valuePtr(e, v.Type().Elem()), e.IsValid()
+}
+
+func StrMapTryGetAs[K comparable, V any](m *Map, mType *MapType, key string) (V, bool) {
+	v, ok := StrMapTryGet(m, mType, key)
+	if !ok {
+		var zero V
+		return zero, ok
+	}
+	return *(*V)(v), ok
+}
+
+func StrMapSet(m *Map, mType *MapType, key string, value unsafe.Pointer) {
+	v := mapValue(m, mType)
+	v.SetMapIndex(mapStrKey(v, key), reflect.NewAt(v.Type().Elem(), value).Elem())
+}
+
+// StrMapSetBytes is StrMapSet for a key given as bytes.
+//
+// In the fallback build the key is always copied.
+func StrMapSetBytes(m *Map, mType *MapType, key []byte, value unsafe.Pointer) {
+	StrMapSet(m, mType, string(key), value)
+}
+
+// StrMapGetOrInsert returns a pointer to the element of key in m.
+// If key is not present, a zeroed element is inserted, init is called
+// with a pointer to it, and inserted is true.
+//
+// In the fallback build the pointer is to a copy of the element. If init
+// panics, key is not inserted.
+func StrMapGetOrInsert(m *Map, mType *MapType, key string, init func(elem unsafe.Pointer)) (elem unsafe.Pointer, inserted bool) {
+	v := mapValue(m, mType)
+	k := mapStrKey(v, key)
+	if e := v.MapIndex(k); e.IsValid() {
+		return valuePtr(e, v.Type().Elem()), false
+	}
+	p := reflect.New(v.Type().Elem())
+	init(p.UnsafePointer())
+	v.SetMapIndex(k, p.Elem())
+	return p.UnsafePointer(), true
+}
+
+// StrMapGetOrInsertAs is like StrMapGetOrInsert but returns the element
+// by value; on a miss the element is set to the result of init.
+func StrMapGetOrInsertAs[K comparable, V any](m *Map, mType *MapType, key string, init func() V) (V, bool) {
+	elem, inserted := StrMapGetOrInsert(m, mType, key, func(elem unsafe.Pointer) {
+		*(*V)(elem) = init()
+	})
+	return *(*V)(elem), inserted
+}
+
+// MapTryAssign returns a pointer to the element of the key at keyPtr in m,
+// inserting a zero element if key is not present.
+//
+// Unlike the runtime it does not panic if key holds, in an interface, a
+// value of an unhashable type; it returns an *ErrUnhashableKey instead and
+// leaves m unchanged. m must not be nil.
+func MapTryAssign(m *Map, mType *MapType, keyPtr unsafe.Pointer) (unsafe.Pointer, error) {
+	keyType := ABITypeToReflectType(&mType.Type).Key()
+	if err := checkHashable(keyType, keyPtr); err != nil {
+		return nil, err
+	}
+	return mapassign(mType, m, keyPtr), nil
+}
+
+func MapClone(m *Map, mType *MapType) any {
+	v := mapValue(m, mType)
+	if v.IsNil() {
+		return v.Interface()
+	}
+	clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		clone.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return clone.Interface()
+}
+
+func MapCloneAs[K comparable, V any](m *Map, mType *MapType) map[K]V {
+	return MapClone(m, mType).(map[K]V)
+}
+
+// MapCloneInto replaces the contents of dst with the entries of src.
+//
+// dst is cleared rather than reallocated, so tables it has already grown
+// are reused when they can hold src. dst must not be nil.
+func MapCloneInto(dst, src *Map, mType *MapType) {
+	if dst == nil {
+		panic("gointernals.MapCloneInto of nil destination map")
+	}
+	if dst == src {
+		return
+	}
+	dstV, srcV := mapValue(dst, mType), mapValue(src, mType)
+	dstV.Clear()
+	iter := srcV.MapRange()
+	for iter.Next() {
+		dstV.SetMapIndex(iter.Key(), iter.Value())
+	}
+}
+
+// MapCloneIntoAs replaces the contents of dst with the entries of src.
+// See MapCloneInto.
+func MapCloneIntoAs[K comparable, V any](dst, src map[K]V) {
+	dstM, mType := MapUnpack(dst)
+	srcM, _ := MapUnpack(src)
+	MapCloneInto(dstM, srcM, mType)
+}
+
+func MapClear(m *Map, mType *MapType) {
+	mapValue(m, mType).Clear()
+}
+
+func MapElemType(mType *MapType) *abi.Type {
+	return ReflectTypeToABIType(ABITypeToReflectType(&mType.Type).Elem())
+}
@@ -0,0 +1,54 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+func TestSafeStrMapGetSet(t *testing.T) {
+	m := map[string]int{"a": 1}
+	mtable, mtype := MapUnpack(m)
+
+	if got := StrMapGetAs[string, int](mtable, mtype, "a"); got != 1 {
+		t.Errorf("StrMapGetAs(a) = %d, want 1", got)
+	}
+	if got := *(*int)(StrMapGet(mtable, mtype, "missing")); got != 0 {
+		t.Errorf("StrMapGet(missing) = %d, want 0", got)
+	}
+	if _, ok := StrMapTryGetAs[string, int](mtable, mtype, "missing"); ok {
+		t.Error("StrMapTryGetAs(missing) reported ok")
+	}
+
+	v := 2
+	StrMapSet(mtable, mtype, "b", unsafe.Pointer(&v))
+	if m["b"] != 2 {
+		t.Errorf("m[b] = %d, want 2", m["b"])
+	}
+}
+
+func TestSafeMapCloneClear(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	mtable, mtype := MapUnpack(m)
+
+	clone := MapCloneAs[string, int](mtable, mtype)
+	if len(clone) != 2 || clone["a"] != 1 || clone["b"] != 2 {
+		t.Errorf("MapCloneAs = %v", clone)
+	}
+	clone["c"] = 3
+	if _, ok := m["c"]; ok {
+		t.Error("clone shares storage with original")
+	}
+
+	if MapElemType(mtype).Kind() != abi.Int {
+		t.Errorf("MapElemType = %s, want int", MapElemType(mtype))
+	}
+
+	MapClear(mtable, mtype)
+	if len(m) != 0 {
+		t.Errorf("len after MapClear = %d, want 0", len(m))
+	}
+}
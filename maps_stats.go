@@ -0,0 +1,141 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe && (go1.26 || goexperiment.swissmap)
+
+package gointernals
+
+import (
+	"unsafe"
+)
+
+// MapTableInfo describes one table of a swiss map.
+type MapTableInfo struct {
+	Used       int   // number of filled slots
+	Capacity   int   // total number of slots
+	GrowthLeft int   // slots that can still be filled before the table grows
+	Tombstones int   // number of deleted slots
+	LocalDepth uint8 // number of hash bits used by directory lookups above this table
+	Groups     int   // number of slot groups
+}
+
+// MapInfo describes the internal state of a swiss map.
+type MapInfo struct {
+	Len int // number of entries
+
+	// Small reports whether the map is a single group without tables.
+	// Small maps never have tombstones.
+	Small bool
+
+	DirLen      int // directory size, 1<<GlobalDepth or 0 for small maps
+	GlobalDepth uint8
+
+	// Tables describes each distinct table, in directory order.
+	// A table appears once even if several directory entries point to it.
+	Tables []MapTableInfo
+
+	// Totals over Tables, or for the single group of a small map.
+	Capacity   int
+	GrowthLeft int
+	Tombstones int
+
+	// TombstonePossible is the map's own hint that a table may contain
+	// tombstones.
+	TombstonePossible bool
+
+	// GroupOccupancy[n] is the number of groups with exactly n full slots.
+	GroupOccupancy [MapGroupSlots + 1]int
+
+	// Bytes is the memory held by the map: the header, the directory,
+	// the tables and their groups.
+	Bytes uintptr
+}
+
+// MapStats returns statistics about the map m of type mType, read from its
+// tables and group control words.
+//
+// It must not be called concurrently with writes to m. It is only available
+// with the swiss table layout: not with GOEXPERIMENT=noswissmap, nor in the
+// fallback build.
+func MapStats(m *Map, mType *MapType) MapInfo {
+	var s MapInfo
+	if m == nil {
+		return s
+	}
+	s.Len = int(m.used)
+	s.GlobalDepth = m.globalDepth
+	s.TombstonePossible = m.tombstonePossible
+	s.Bytes = mapFootprint(m, mType)
+
+	if m.dirLen == 0 {
+		s.Small = true
+		if m.dirPtr == nil {
+			return s
+		}
+		s.Capacity = MapGroupSlots
+		s.GrowthLeft = MapGroupSlots - s.Len
+		s.countGroup(m.dirPtr)
+		return s
+	}
+
+	s.DirLen = m.dirLen
+	dir := unsafe.Slice((**MapTable)(m.dirPtr), m.dirLen)
+	for i := 0; i < len(dir); {
+		t := dir[i]
+		ts := MapTableInfo{
+			Used:       int(t.used),
+			Capacity:   int(t.capacity),
+			GrowthLeft: int(t.growthLeft),
+			LocalDepth: t.localDepth,
+			Groups:     int(t.groups.lengthMask + 1),
+		}
+		for g := range uintptr(ts.Groups) {
+			ts.Tombstones += s.countGroup(unsafe.Add(t.groups.data, g*mType.GroupSize))
+		}
+		s.Tables = append(s.Tables, ts)
+		s.Capacity += ts.Capacity
+		s.GrowthLeft += ts.GrowthLeft
+		s.Tombstones += ts.Tombstones
+
+		// A table with local depth d fills 1<<(globalDepth-d) consecutive
+		// directory entries.
+		i += 1 << (m.globalDepth - t.localDepth)
+	}
+	return s
+}
+
+// mapFootprint returns the memory held by the map m: the header, the
+// directory, the tables and their groups. Keys and elements stored
+// indirectly are not counted.
+func mapFootprint(m *Map, mType *MapType) uintptr {
+	if m == nil {
+		return 0
+	}
+	n := unsafe.Sizeof(Map{})
+	if m.dirLen == 0 {
+		if m.dirPtr != nil {
+			n += mType.GroupSize
+		}
+		return n
+	}
+	n += uintptr(m.dirLen) * unsafe.Sizeof(uintptr(0))
+	dir := unsafe.Slice((**MapTable)(m.dirPtr), m.dirLen)
+	for i := 0; i < len(dir); i += 1 << (m.globalDepth - dir[i].localDepth) {
+		n += unsafe.Sizeof(MapTable{}) + uintptr(dir[i].groups.lengthMask+1)*mType.GroupSize
+	}
+	return n
+}
+
+// countGroup adds the group at g to the occupancy histogram and returns
+// its number of deleted slots.
+func (s *MapInfo) countGroup(g unsafe.Pointer) (deleted int) {
+	ctrl := *(*uint64)(g)
+	full := 0
+	for i := range MapGroupSlots {
+		switch c := uint8(ctrl >> (8 * i)); {
+		case c == ctrlDeleted:
+			deleted++
+		case c&ctrlEmpty == 0:
+			full++
+		}
+	}
+	s.GroupOccupancy[full]++
+	return deleted
+}
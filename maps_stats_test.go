@@ -0,0 +1,113 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe && (go1.26 || goexperiment.swissmap)
+
+package gointernals
+
+import (
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+func TestMapStatsSmall(t *testing.T) {
+	var nilMap map[string]int
+	if s := MapStats(MapUnpack(nilMap)); s.Len != 0 || s.Bytes != 0 {
+		t.Errorf("MapStats(nil) = %+v, want zero", s)
+	}
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	mtable, mtype := MapUnpack(m)
+	s := MapStats(mtable, mtype)
+	if !s.Small || s.DirLen != 0 || len(s.Tables) != 0 {
+		t.Fatalf("MapStats of small map = %+v", s)
+	}
+	if s.Len != 3 || s.Capacity != MapGroupSlots || s.GrowthLeft != MapGroupSlots-3 {
+		t.Errorf("Len/Capacity/GrowthLeft = %d/%d/%d, want 3/%d/%d", s.Len, s.Capacity, s.GrowthLeft, MapGroupSlots, MapGroupSlots-3)
+	}
+	if s.GroupOccupancy[3] != 1 {
+		t.Errorf("GroupOccupancy = %v, want one group with 3 slots", s.GroupOccupancy)
+	}
+	if want := unsafe.Sizeof(Map{}) + mtype.GroupSize; s.Bytes != want {
+		t.Errorf("Bytes = %d, want %d", s.Bytes, want)
+	}
+}
+
+func TestMapStatsTables(t *testing.T) {
+	m := make(map[int]int)
+	for i := range 5000 {
+		m[i] = i
+	}
+	for i := 0; i < 5000; i += 2 {
+		delete(m, i)
+	}
+	mtable, mtype := MapUnpack(m)
+	s := MapStats(mtable, mtype)
+
+	if s.Small || s.DirLen != 1<<s.GlobalDepth || len(s.Tables) == 0 {
+		t.Fatalf("MapStats = %+v", s)
+	}
+	used, full, bytes := 0, 0, unsafe.Sizeof(Map{})+uintptr(s.DirLen)*unsafe.Sizeof(uintptr(0))
+	for _, ts := range s.Tables {
+		used += ts.Used
+		if ts.Capacity != ts.Groups*MapGroupSlots {
+			t.Errorf("table Capacity = %d, want %d", ts.Capacity, ts.Groups*MapGroupSlots)
+		}
+		if ts.LocalDepth > s.GlobalDepth {
+			t.Errorf("table LocalDepth %d > GlobalDepth %d", ts.LocalDepth, s.GlobalDepth)
+		}
+		// growthLeft starts at 7/8 of capacity. Inserts take from it, and
+		// deletes either give the slot back or leave a tombstone.
+		if got, want := ts.Used+ts.Tombstones+ts.GrowthLeft, ts.Capacity*7/8; got != want {
+			t.Errorf("table Used+Tombstones+GrowthLeft = %d, want %d", got, want)
+		}
+		bytes += unsafe.Sizeof(MapTable{}) + uintptr(ts.Groups)*mtype.GroupSize
+	}
+	for n, groups := range s.GroupOccupancy {
+		full += n * groups
+	}
+	if used != len(m) || full != len(m) || s.Len != len(m) {
+		t.Errorf("Len/table used/full slots = %d/%d/%d, want %d", s.Len, used, full, len(m))
+	}
+	if s.Bytes != bytes {
+		t.Errorf("Bytes = %d, want %d", s.Bytes, bytes)
+	}
+}
+
+func TestMapCloneIntoReusesTables(t *testing.T) {
+	src := make(map[int]int)
+	for i := range 1000 {
+		src[i] = i
+	}
+	dst := make(map[int]int, 2*len(src))
+	dstM, mType := MapUnpack(dst)
+	before := MapStats(dstM, mType)
+
+	MapCloneIntoAs(dst, src)
+	after := MapStats(dstM, mType)
+	if after.Len != len(src) {
+		t.Errorf("Len = %d, want %d", after.Len, len(src))
+	}
+	if after.Capacity != before.Capacity || after.Bytes != before.Bytes {
+		t.Errorf("Capacity/Bytes = %d/%d, want the presized %d/%d", after.Capacity, after.Bytes, before.Capacity, before.Bytes)
+	}
+}
+
+func TestMapCompactReclaimsTables(t *testing.T) {
+	m := make(map[string]int)
+	for i := range 10000 {
+		m[strconv.Itoa(i)] = i
+	}
+	for i := 10; i < 10000; i++ {
+		delete(m, strconv.Itoa(i))
+	}
+	mtable, mType := MapUnpack(m)
+	before := MapStats(mtable, mType)
+
+	compacted, reclaimed := MapCompact(mtable, mType)
+	after := MapStats(compacted, mType)
+	if after.Len != 10 || after.Tombstones != 0 {
+		t.Errorf("compacted Len/Tombstones = %d/%d, want 10/0", after.Len, after.Tombstones)
+	}
+	if reclaimed <= 0 || reclaimed != int(before.Bytes)-int(after.Bytes) {
+		t.Errorf("reclaimed = %d, want %d - %d", reclaimed, before.Bytes, after.Bytes)
+	}
+}
@@ -1,4 +1,4 @@
-//go:build go1.24 && !go1.27
+//go:build go1.24 && !go1.27 && !gointernals_safe
 
 package gointernals
 
@@ -297,3 +297,30 @@ func TestMapClear(t *testing.T) {
 		}
 	})
 }
+
+func TestCanFastKey(t *testing.T) {
+	type pair struct{ a, b int32 }
+	tests := []struct {
+		name  string
+		mType *MapType
+		want  bool
+	}{
+		{"int", mapTypeOf(map[int]int{}), true},
+		{"uint32", mapTypeOf(map[uint32]int{}), true},
+		{"pointer", mapTypeOf(map[*int]int{}), true},
+		{"struct", mapTypeOf(map[pair]int{}), true},
+		{"float64", mapTypeOf(map[float64]int{}), false},
+		{"string", mapTypeOf(map[string]int{}), false},
+		{"large elem", mapTypeOf(map[int][200]byte{}), false},
+	}
+	for _, tt := range tests {
+		if got := canFastKey(tt.mType); got != tt.want {
+			t.Errorf("canFastKey(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func mapTypeOf[K comparable, V any](m map[K]V) *MapType {
+	_, mType := MapUnpack(m)
+	return mType
+}
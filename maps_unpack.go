@@ -0,0 +1,29 @@
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+//go:nosplit
+func MapUnpack[K comparable, V any](m map[K]V) (*Map, *MapType) {
+	eface := EfaceOf(m)
+	return (*Map)(unsafe.Pointer(eface.Data)), (*MapType)(abi.NoEscape(unsafe.Pointer(eface.Type)))
+}
+
+//go:nosplit
+func StrMapCast[K ~string, V any, M ~map[K]V](m M) map[string]V {
+	return *(*map[string]V)(unsafe.Pointer(&m))
+}
+
+func MapToEface(m *Map, mType *MapType) *abi.Eface {
+	return &abi.Eface{
+		Data: unsafe.Pointer(m),
+		Type: (*abi.Type)(abi.NoEscape(unsafe.Pointer(mType))),
+	}
+}
+
+func MapToAny(m *Map, mType *MapType) any {
+	return AnyFrom(MapToEface(m, mType))
+}
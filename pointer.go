@@ -1,3 +1,5 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
 package gointernals
 
 import (
@@ -11,6 +13,10 @@ type PointerType struct {
 	Elem *abi.Type
 }
 
+func TypeFor[T any]() *abi.Type {
+	return (*PointerType)(abi.NoEscape(unsafe.Pointer(TypeOf((*T)(nil))))).Elem
+}
+
 //go:nosplit
 func PointerCast[ToT any, FromT any](src *FromT) *ToT {
 	return (*ToT)(unsafe.Pointer(src))
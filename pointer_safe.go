@@ -0,0 +1,19 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+func TypeFor[T any]() *abi.Type {
+	return ReflectTypeToABIType(reflect.TypeFor[T]())
+}
+
+//go:nosplit
+func PointerCast[ToT any, FromT any](src *FromT) *ToT {
+	return (*ToT)(unsafe.Pointer(src))
+}
@@ -1,4 +1,4 @@
-//go:build go1.24 && !go1.27
+//go:build go1.24 && !go1.27 && !gointernals_safe
 
 package gointernals
 
@@ -22,11 +22,6 @@ func reflect_unsafe_NewArray(*abi.Type, int) unsafe.Pointer
 //go:linkname reflect_growslice reflect.growslice
 func reflect_growslice(t *abi.Type, old Slice, num int) Slice
 
-//go:nosplit
-func ReflectTypeToABIType(t reflect.Type) *abi.Type {
-	return (*abi.Type)(abi.NoEscape(EfaceOf(t).Data))
-}
-
 //go:nosplit
 func ReflectValueType(v reflect.Value) *abi.Type {
 	return *(**abi.Type)(abi.NoEscape(unsafe.Pointer(&v)))
@@ -82,21 +77,5 @@ func ReflectShallowCopy(dst, src reflect.Value) {
 		return
 	}
 
-	panic(fmt.Errorf("gointernals.ReflectShallowCopy: invalid shallow copy from %s to %s", dstT.Kind().String(), srcT.Kind().String()))
-}
-
-func ReflectIsNumeric(v reflect.Value) bool {
-	return v.Kind() >= reflect.Int && v.Kind() <= reflect.Float64
-}
-
-func ReflectCanInt(v reflect.Value) bool {
-	return v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64
-}
-
-func ReflectCanUint(v reflect.Value) bool {
-	return v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64
-}
-
-func ReflectCanFloat(v reflect.Value) bool {
-	return v.Kind() >= reflect.Float32 && v.Kind() <= reflect.Float64
+	panic(fmt.Errorf("gointernals.ReflectShallowCopy: invalid shallow copy from %s to %s", srcT.String(), dstT.String()))
 }
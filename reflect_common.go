@@ -0,0 +1,82 @@
+package gointernals
+
+import (
+	"errors"
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+//go:nosplit
+func ReflectTypeToABIType(t reflect.Type) *abi.Type {
+	return (*abi.Type)(abi.NoEscape(EfaceOf(t).Data))
+}
+
+// ABITypeToReflectType returns the reflect.Type for t.
+func ABITypeToReflectType(t *abi.Type) reflect.Type {
+	return reflect.TypeOf(AnyFrom(&abi.Eface{Type: t}))
+}
+
+//go:nosplit
+func ReflectMapUnpack(dst reflect.Value) (*Map, *MapType) {
+	eface := EfaceOf(dst.Interface())
+	return (*Map)(unsafe.Pointer(eface.Data)), (*MapType)(abi.NoEscape(unsafe.Pointer(eface.Type)))
+}
+
+func ReflectIsNumeric(v reflect.Value) bool {
+	return v.Kind() >= reflect.Int && v.Kind() <= reflect.Float64
+}
+
+func ReflectCanInt(v reflect.Value) bool {
+	return v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64
+}
+
+func ReflectCanUint(v reflect.Value) bool {
+	return v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uint64
+}
+
+func ReflectCanFloat(v reflect.Value) bool {
+	return v.Kind() >= reflect.Float32 && v.Kind() <= reflect.Float64
+}
+
+// checkReflectMapAssign panics if dst is not a non-nil map.
+func checkReflectMapAssign(fn string, dst reflect.Value) {
+	if dst.Kind() != reflect.Map {
+		panic("gointernals." + fn + " of non map type")
+	}
+	if dst.IsNil() {
+		panic("gointernals." + fn + " of nil map")
+	}
+}
+
+// reflectMapKey returns key as an addressable value of the key type of
+// the map dst. An invalid key is the nil value of an interface key type.
+func reflectMapKey(fn string, dst, key reflect.Value) (reflect.Value, error) {
+	keyType := dst.Type().Key()
+	keyPtr := reflect.New(keyType)
+	if !key.IsValid() {
+		if keyType.Kind() != reflect.Interface {
+			return reflect.Value{}, errors.New("gointernals." + fn + " nil key for non-interface map key type")
+		}
+		return keyPtr.Elem(), nil
+	}
+	// Ensure key is of the exact map key type
+	if !key.Type().AssignableTo(keyType) {
+		if !key.Type().ConvertibleTo(keyType) {
+			return reflect.Value{}, errors.New("gointernals." + fn + " key not assignable to map key type")
+		}
+		key = key.Convert(keyType)
+	}
+	keyPtr.Elem().Set(key)
+	return keyPtr.Elem(), nil
+}
+
+// mustReflectMapKey is reflectMapKey but panics on error.
+func mustReflectMapKey(fn string, dst, key reflect.Value) reflect.Value {
+	k, err := reflectMapKey(fn, dst, key)
+	if err != nil {
+		panic(err.Error())
+	}
+	return k
+}
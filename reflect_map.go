@@ -1,11 +1,11 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
 package gointernals
 
 import (
 	"reflect"
 	"unsafe"
 
-	_ "unsafe"
-
 	"github.com/yusing/gointernals/abi"
 )
 
@@ -27,12 +27,6 @@ func ReflectInitMap(dst reflect.Value, len int) {
 	ReflectValueSet(dst, newMap)
 }
 
-//go:nosplit
-func ReflectMapUnpack(dst reflect.Value) (*Map, *MapType) {
-	eface := EfaceOf(dst.Interface())
-	return (*Map)(unsafe.Pointer(eface.Data)), (*MapType)(abi.NoEscape(unsafe.Pointer(eface.Type)))
-}
-
 // ReflectStrMapAssign assigns a string key to a map and returns the value.
 //
 // The returned value should satisfy CanSet().
@@ -47,43 +41,97 @@ func ReflectStrMapAssign(dst reflect.Value, key string) reflect.Value {
 	}
 
 	m, mType := ReflectMapUnpack(dst)
-	elemPtr := mapassign_faststr(mType, m, key)
+	elemPtr := strmapassign(mType, m, key)
 	return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem()
 }
 
 // ReflectMapAssign assigns a key to a map and returns the value.
 //
+// If the dynamic type of key is the map key type it is used as is;
+// otherwise it is assigned or converted to the key type.
+// String, 4-byte and 8-byte keys use the runtime's fast map routines.
+//
 // The returned value should satisfy CanSet().
 //
 //go:nosplit
 func ReflectMapAssign(dst reflect.Value, key any) reflect.Value {
-	if dst.Kind() != reflect.Map {
-		panic("gointernals.ReflectMapAssign of non map type")
-	}
-	if dst.IsNil() {
-		panic("gointernals.ReflectMapAssign of nil map")
-	}
+	checkReflectMapAssign("ReflectMapAssign", dst)
 
-	// fast path (same type)
+	// fast path (key is of the map key type)
 	m, mType := ReflectMapUnpack(dst)
-	keyType := dst.Type().Key()
-	if keyType.Kind() == dst.Type().Elem().Kind() {
-		elemPtr := mapassign(mType, m, EfaceOf(key).Data)
-		return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem()
+	if e := (*abi.Eface)(unsafe.Pointer(&key)); e.Type == mType.Key {
+		return reflectMapAssignAt(dst, m, mType, efaceValuePtr(e))
 	}
 
 	// slow path (any / interface key)
-	keyVal := reflect.ValueOf(key)
-	// Ensure key is of the exact map key type
-	if !keyVal.Type().AssignableTo(keyType) {
-		if keyVal.Type().ConvertibleTo(keyType) {
-			keyVal = keyVal.Convert(keyType)
-		} else {
-			panic("gointernals.ReflectMapAssign key not assignable to map key type")
+	keyVal := mustReflectMapKey("ReflectMapAssign", dst, reflect.ValueOf(key))
+	return reflectMapAssignAt(dst, m, mType, reflectValueDataPtr(&keyVal))
+}
+
+// ReflectMapAssignValue is like ReflectMapAssign but takes the key as a
+// reflect.Value.
+//
+//go:nosplit
+func ReflectMapAssignValue(dst, key reflect.Value) reflect.Value {
+	checkReflectMapAssign("ReflectMapAssignValue", dst)
+
+	m, mType := ReflectMapUnpack(dst)
+	if !key.IsValid() || ReflectValueType(key) != mType.Key {
+		key = mustReflectMapKey("ReflectMapAssignValue", dst, key)
+	}
+	return reflectMapAssignAt(dst, m, mType, reflectValueDataPtr(&key))
+}
+
+// ReflectMapTryAssign is like ReflectMapAssign but returns an error
+// instead of panicking if key cannot be used as a key of dst. If key holds
+// a value of an unhashable type the error is an *ErrUnhashableKey.
+//
+// It still panics if dst is not a non-nil map.
+func ReflectMapTryAssign(dst reflect.Value, key any) (reflect.Value, error) {
+	checkReflectMapAssign("ReflectMapTryAssign", dst)
+
+	m, mType := ReflectMapUnpack(dst)
+	var keyPtr unsafe.Pointer
+	if e := (*abi.Eface)(unsafe.Pointer(&key)); e.Type == mType.Key {
+		keyPtr = efaceValuePtr(e)
+	} else {
+		keyVal, err := reflectMapKey("ReflectMapTryAssign", dst, reflect.ValueOf(key))
+		if err != nil {
+			return reflect.Value{}, err
 		}
+		keyPtr = reflectValueDataPtr(&keyVal)
+	}
+	elemPtr, err := MapTryAssign(m, mType, keyPtr)
+	if err != nil {
+		return reflect.Value{}, err
 	}
-	keyPtr := reflect.New(keyType)
-	keyPtr.Elem().Set(keyVal)
-	elemPtr := mapassign(mType, m, keyPtr.UnsafePointer())
+	return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem(), nil
+}
+
+// reflectMapAssignAt assigns the key stored at keyPtr to the map dst and
+// returns the settable element.
+func reflectMapAssignAt(dst reflect.Value, m *Map, mType *MapType, keyPtr unsafe.Pointer) reflect.Value {
+	elemPtr := mapassignKey(mType, m, keyPtr)
 	return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem()
 }
+
+// ReflectMapDelete deletes key from the map dst.
+// It is a no-op if dst is nil or key is not present.
+//
+//go:nosplit
+func ReflectMapDelete(dst reflect.Value, key any) {
+	if dst.Kind() != reflect.Map {
+		panic("gointernals.ReflectMapDelete of non map type")
+	}
+
+	// fast path (key is of the map key type)
+	m, mType := ReflectMapUnpack(dst)
+	if e := (*abi.Eface)(abi.NoEscape(unsafe.Pointer(&key))); e.Type == mType.Key {
+		mapdelete(mType, m, efaceValuePtr(e))
+		return
+	}
+
+	// slow path (any / interface key)
+	keyVal := mustReflectMapKey("ReflectMapDelete", dst, reflect.ValueOf(key))
+	mapdelete(mType, m, reflectValueDataPtr(&keyVal))
+}
@@ -0,0 +1,101 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// mapassign is the only runtime function the fallback links to. reflect
+// offers no way to get a settable map element, and mapassign takes only
+// opaque pointers; its signature is frozen by go.dev/issue/67401.
+//
+//go:linkname mapassign runtime.mapassign
+//go:noescape
+func mapassign(t *MapType, m *Map, key unsafe.Pointer) unsafe.Pointer
+
+func ReflectInitMap(dst reflect.Value, len int) {
+	if dst.Kind() != reflect.Map {
+		panic("gointernals.ReflectInitMap of non-map type")
+	}
+
+	ReflectValueSet(dst, reflect.MakeMapWithSize(dst.Type(), len).UnsafePointer())
+}
+
+// ReflectStrMapAssign assigns a string key to a map and returns the value.
+//
+// The returned value should satisfy CanSet().
+func ReflectStrMapAssign(dst reflect.Value, key string) reflect.Value {
+	if dst.Kind() != reflect.Map || dst.Type().Key().Kind() != reflect.String {
+		panic("gointernals.ReflectStrMapAssign of non map or non-string map type")
+	}
+	if dst.IsNil() {
+		panic("gointernals.ReflectStrMapAssign of nil map")
+	}
+
+	m, mType := ReflectMapUnpack(dst)
+	keyPtr := reflect.New(dst.Type().Key())
+	keyPtr.Elem().SetString(key)
+	elemPtr := mapassign(mType, m, keyPtr.UnsafePointer())
+	return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem()
+}
+
+// ReflectMapAssign assigns a key to a map and returns the value.
+//
+// If the dynamic type of key is not the map key type it is assigned or
+// converted to the key type.
+//
+// The returned value should satisfy CanSet().
+func ReflectMapAssign(dst reflect.Value, key any) reflect.Value {
+	checkReflectMapAssign("ReflectMapAssign", dst)
+	return reflectMapAssign(dst, mustReflectMapKey("ReflectMapAssign", dst, reflect.ValueOf(key)))
+}
+
+// ReflectMapAssignValue is like ReflectMapAssign but takes the key as a
+// reflect.Value.
+func ReflectMapAssignValue(dst, key reflect.Value) reflect.Value {
+	checkReflectMapAssign("ReflectMapAssignValue", dst)
+	return reflectMapAssign(dst, mustReflectMapKey("ReflectMapAssignValue", dst, key))
+}
+
+// ReflectMapTryAssign is like ReflectMapAssign but returns an error
+// instead of panicking if key cannot be used as a key of dst. If key holds
+// a value of an unhashable type the error is an *ErrUnhashableKey.
+//
+// It still panics if dst is not a non-nil map.
+func ReflectMapTryAssign(dst reflect.Value, key any) (reflect.Value, error) {
+	checkReflectMapAssign("ReflectMapTryAssign", dst)
+
+	keyVal, err := reflectMapKey("ReflectMapTryAssign", dst, reflect.ValueOf(key))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	m, mType := ReflectMapUnpack(dst)
+	elemPtr, err := MapTryAssign(m, mType, keyVal.Addr().UnsafePointer())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem(), nil
+}
+
+// reflectMapAssign assigns key, which must be an addressable value of the
+// key type of dst, to the map dst and returns the settable element.
+func reflectMapAssign(dst, key reflect.Value) reflect.Value {
+	m, mType := ReflectMapUnpack(dst)
+	elemPtr := mapassign(mType, m, key.Addr().UnsafePointer())
+	return reflect.NewAt(dst.Type().Elem(), elemPtr).Elem()
+}
+
+// ReflectMapDelete deletes key from the map dst.
+// It is a no-op if dst is nil or key is not present.
+func ReflectMapDelete(dst reflect.Value, key any) {
+	if dst.Kind() != reflect.Map {
+		panic("gointernals.ReflectMapDelete of non map type")
+	}
+	if dst.IsNil() {
+		return
+	}
+
+	dst.SetMapIndex(mustReflectMapKey("ReflectMapDelete", dst, reflect.ValueOf(key)), reflect.Value{})
+}
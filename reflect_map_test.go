@@ -1,5 +1,3 @@
-//go:build go1.24 && !go1.27
-
 package gointernals
 
 import (
@@ -616,3 +614,70 @@ func TestReflectMapAssign_PanicOnNonMap(t *testing.T) {
 	}()
 	_ = ReflectMapAssign(iv, 1)
 }
+
+func TestReflectMapAssign_FastKeyKinds(t *testing.T) {
+	type big struct{ A [200]byte }
+	x, y := new(int), new(int)
+
+	m32 := map[uint32]int{}
+	ReflectMapAssign(reflect.ValueOf(m32), uint32(1)).SetInt(1)
+	ReflectMapAssign(reflect.ValueOf(m32), 2).SetInt(2) // int converted to uint32
+	m64 := map[int64]string{}
+	ReflectMapAssign(reflect.ValueOf(m64), int64(1)).SetString("a")
+	mptr := map[*int]int{x: 1}
+	ReflectMapAssign(reflect.ValueOf(mptr), y).SetInt(2)
+	mstr := map[string]big{}
+	ReflectMapAssign(reflect.ValueOf(mstr), "k").Field(0).Index(199).SetUint(7)
+
+	if m32[1] != 1 || m32[2] != 2 || m64[1] != "a" || mptr[x] != 1 || mptr[y] != 2 || mstr["k"].A[199] != 7 {
+		t.Fatalf("unexpected map contents: %v %v %v %v", m32, m64, mptr, len(mstr))
+	}
+}
+
+func TestReflectMapAssign_NamedKeyType(t *testing.T) {
+	type name string
+	m := map[name]int{}
+	mv := reflect.ValueOf(m)
+
+	ReflectMapAssign(mv, name("a")).SetInt(1)
+	ReflectMapAssign(mv, "b").SetInt(2)
+	if m["a"] != 1 || m["b"] != 2 || len(m) != 2 {
+		t.Fatalf("unexpected map contents: %v", m)
+	}
+}
+
+func TestReflectMapAssign_NilInterfaceKey(t *testing.T) {
+	m := map[any]int{}
+	ReflectMapAssign(reflect.ValueOf(m), nil).SetInt(1)
+	if v, ok := m[nil]; !ok || v != 1 {
+		t.Fatalf("m[nil] = %d, %v, want 1, true", v, ok)
+	}
+}
+
+func TestReflectMapAssignValue(t *testing.T) {
+	type pair struct {
+		P *int
+		S string
+	}
+	p := pair{P: new(int), S: "s"}
+	pv := reflect.ValueOf(&p).Elem()
+
+	mptr := map[*int]int{}
+	ReflectMapAssignValue(reflect.ValueOf(mptr), pv.Field(0)).SetInt(1)          // indirect
+	ReflectMapAssignValue(reflect.ValueOf(mptr), reflect.ValueOf(p.P)).SetInt(2) // direct
+	mstr := map[string]int{}
+	ReflectMapAssignValue(reflect.ValueOf(mstr), pv.Field(1)).SetInt(3)
+	many := map[any]int{}
+	ReflectMapAssignValue(reflect.ValueOf(many), reflect.ValueOf(1.5)).SetInt(4)
+
+	if len(mptr) != 1 || mptr[p.P] != 2 || mstr["s"] != 3 || many[1.5] != 4 {
+		t.Fatalf("unexpected map contents: %v %v %v", mptr, mstr, many)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic on unconvertible key")
+		}
+	}()
+	ReflectMapAssignValue(reflect.ValueOf(mstr), reflect.ValueOf(1.5))
+}
@@ -0,0 +1,68 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+func ReflectValueType(v reflect.Value) *abi.Type {
+	return ReflectTypeToABIType(v.Type())
+}
+
+// ReflectValueData returns a pointer to the data held by v, or for
+// pointer-shaped types, the pointer itself.
+//
+// In the fallback build the pointer is to a copy of the data unless v is
+// addressable or pointer-shaped.
+func ReflectValueData(v reflect.Value) unsafe.Pointer {
+	if v.CanAddr() {
+		return unsafe.Pointer(v.UnsafeAddr())
+	}
+	if ReflectValueType(v).IsDirectIface() {
+		return EfaceOf(v.Interface()).Data
+	}
+	return valuePtr(v, v.Type())
+}
+
+func ReflectValueSet[T any](v reflect.Value, x T) {
+	*(*T)(ReflectValueData(v)) = x
+}
+
+func ReflectValueAs[T any](v reflect.Value) T {
+	return *(*T)(ReflectValueData(v))
+}
+
+func ReflectInitPtr(v reflect.Value) {
+	t := v.Type()
+	switch t.Kind() {
+	case reflect.Pointer:
+		ReflectValueSet(v, reflect.New(t.Elem()).UnsafePointer())
+		return
+	}
+
+	panic(fmt.Errorf("gointernals.ReflectInitPtr: invalid type %s", t.Kind().String()))
+}
+
+// ReflectShallowCopy copies the value of src to dst.
+// It will panic if the types are not compatible.
+// Note: this function does not update type and flag fields in dst.
+func ReflectShallowCopy(dst, src reflect.Value) {
+	dstT, srcT := dst.Type(), src.Type()
+	target := reflect.NewAt(dstT, ReflectValueData(dst)).Elem()
+	if srcT.AssignableTo(dstT) {
+		target.Set(src)
+		return
+	}
+
+	if srcT.ConvertibleTo(dstT) {
+		target.Set(src.Convert(dstT))
+		return
+	}
+
+	panic(fmt.Errorf("gointernals.ReflectShallowCopy: invalid shallow copy from %s to %s", srcT.String(), dstT.String()))
+}
@@ -0,0 +1,70 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSafeReflectValueData(t *testing.T) {
+	x := 42
+	v := reflect.ValueOf(&x).Elem()
+	if got := ReflectValueAs[int](v); got != 42 {
+		t.Errorf("ReflectValueAs = %d, want 42", got)
+	}
+	ReflectValueSet(v, 7)
+	if x != 7 {
+		t.Errorf("x = %d after ReflectValueSet, want 7", x)
+	}
+
+	var p *int
+	ReflectInitPtr(reflect.ValueOf(&p).Elem())
+	if p == nil {
+		t.Error("ReflectInitPtr left pointer nil")
+	}
+}
+
+func TestSafeReflectMapAssign(t *testing.T) {
+	var m map[string]int
+	mv := reflect.ValueOf(&m).Elem()
+	ReflectInitMap(mv, 1)
+	if m == nil {
+		t.Fatal("ReflectInitMap left map nil")
+	}
+
+	ReflectStrMapAssign(mv, "a").SetInt(1)
+	ReflectMapAssign(mv, "b").SetInt(2)
+	ReflectMapAssignValue(mv, reflect.ValueOf("c")).SetInt(3)
+	if m["a"] != 1 || m["b"] != 2 || m["c"] != 3 {
+		t.Errorf("m = %v, want map[a:1 b:2 c:3]", m)
+	}
+}
+
+func TestSafeReflectSlice(t *testing.T) {
+	var s []any
+	sv := reflect.ValueOf(&s).Elem()
+	ReflectInitSlice(sv, 2, 4)
+	if len(s) != 2 || cap(s) < 4 {
+		t.Fatalf("len, cap = %d, %d, want 2, >=4", len(s), cap(s))
+	}
+	ReflectSetSliceAt(sv, 1, reflect.ValueOf("x"))
+	if s[1] != "x" {
+		t.Errorf("s[1] = %v, want x", s[1])
+	}
+
+	ints := []int{1, 2}
+	ReflectSetSliceAt(reflect.ValueOf(ints), 0, reflect.ValueOf(5))
+	if ints[0] != 5 {
+		t.Errorf("ints[0] = %d, want 5", ints[0])
+	}
+}
+
+func TestSafeReflectShallowCopy(t *testing.T) {
+	type myInt int
+	var dst myInt
+	ReflectShallowCopy(reflect.ValueOf(&dst).Elem(), reflect.ValueOf(3))
+	if dst != 3 {
+		t.Errorf("dst = %d, want 3", dst)
+	}
+}
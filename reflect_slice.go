@@ -1,3 +1,5 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
 package gointernals
 
 import (
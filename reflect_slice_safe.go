@@ -0,0 +1,69 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+)
+
+func ReflectMakeSlice(typ reflect.Type, len, cap int) Slice {
+	if typ.Kind() != reflect.Slice {
+		panic("gointernals.ReflectMakeSlice of non-slice type")
+	}
+	if len < 0 {
+		panic("gointernals.ReflectMakeSlice: negative len")
+	}
+	if cap < 0 {
+		panic("gointernals.ReflectMakeSlice: negative cap")
+	}
+	if len > cap {
+		panic("gointernals.ReflectMakeSlice: len > cap")
+	}
+	s := reflect.MakeSlice(typ, len, cap)
+	return Slice{ptr: s.UnsafePointer(), len: len, cap: cap}
+}
+
+// ReflectInitSlice initializes a slice with the given length and capacity.
+func ReflectInitSlice(dst reflect.Value, len, cap int) {
+	if dst.Kind() != reflect.Slice {
+		panic("gointernals.ReflectInitSlice of non-slice type")
+	}
+
+	s := (*Slice)(ReflectValueData(dst))
+	if s.ptr != nil {
+		if s.cap < cap {
+			sv := reflect.NewAt(dst.Type(), ReflectValueData(dst)).Elem()
+			sv.Grow(cap - sv.Len())
+		}
+		s.len = len
+		return
+	}
+
+	// dst is nil, assign new slice
+	*s = ReflectMakeSlice(dst.Type(), len, cap)
+}
+
+func ReflectSetSliceAt(dst reflect.Value, index int, value reflect.Value) {
+	if dst.Kind() != reflect.Slice {
+		panic("gointernals.ReflectSetSliceAt of non-slice type")
+	}
+	if index < 0 || index >= dst.Len() {
+		panic("gointernals.ReflectSetSliceAt: index out of range")
+	}
+
+	elemType := dst.Type().Elem()
+	elemDst := dst.Index(index).Addr().UnsafePointer()
+
+	// Interface element types need special handling:
+	// the value's concrete type layout differs from eface/iface layout,
+	// so we delegate to reflect which handles the conversion correctly.
+	if elemType.Kind() == reflect.Interface {
+		reflect.NewAt(elemType, elemDst).Elem().Set(value)
+		return
+	}
+
+	if elemType.Size() != value.Type().Size() {
+		panic("gointernals.ReflectSetSliceAt: element type size mismatch")
+	}
+	reflect.NewAt(value.Type(), elemDst).Elem().Set(value)
+}
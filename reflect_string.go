@@ -85,11 +85,11 @@ func ReflectStrToNumBool(dst reflect.Value, src string) error {
 	return nil
 }
 
-var fmtStringerType = reflect.TypeFor[fmt.Stringer]()
+var fmtStringerType = TypeFor[fmt.Stringer]()
 
 func ReflectToStr(v reflect.Value) string {
 	switch {
-	case v.Type().Implements(fmtStringerType):
+	case Implements(ReflectTypeToABIType(v.Type()), fmtStringerType):
 		return v.Interface().(fmt.Stringer).String()
 	case ReflectCanInt(v):
 		switch abi.Kind(v.Kind()).Size() {
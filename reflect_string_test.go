@@ -1,5 +1,3 @@
-//go:build go1.24 && !go1.27
-
 package gointernals
 
 import (
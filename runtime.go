@@ -1,3 +1,5 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
 package gointernals
 
 import (
@@ -8,9 +10,6 @@ import (
 
 // Functions below pushed from runtime.
 
-//go:linkname fatal runtime.fatal
-func fatal(s string)
-
 //go:linkname rand runtime.rand
 func rand() uint64
 
@@ -22,14 +21,6 @@ func memmove(dst, src unsafe.Pointer, n uintptr)
 //go:noescape
 func typedmemmove(typ *abi.Type, dst, src unsafe.Pointer)
 
-//go:linkname typedmemclr runtime.typedmemclr
-//go:noescape
-func typedmemclr(typ *abi.Type, ptr unsafe.Pointer)
-
 //go:linkname newarray runtime.newarray
 //go:noescape
 func newarray(typ *abi.Type, n int) unsafe.Pointer
-
-//go:linkname newobject runtime.newobject
-//go:noescape
-func newobject(typ *abi.Type) unsafe.Pointer
@@ -1,4 +1,4 @@
-//go:build go1.24 && !go1.27
+//go:build go1.24 && !go1.27 && !gointernals_safe
 
 package gointernals
 
@@ -8,24 +8,6 @@ import (
 	"github.com/yusing/gointernals/abi"
 )
 
-type Slice struct {
-	ptr unsafe.Pointer
-	len int
-	cap int
-}
-
-func (s *Slice) Ptr() unsafe.Pointer {
-	return s.ptr
-}
-
-func (s *Slice) Len() int {
-	return s.len
-}
-
-func (s *Slice) Cap() int {
-	return s.cap
-}
-
 // internal/abi/type.go
 type SliceType struct {
 	abi.Type
@@ -42,12 +24,6 @@ func growslice(oldPtr unsafe.Pointer, newLen, oldCap, num int, et *abi.Type) Sli
 //go:noescape
 func makeslice(et *abi.Type, len, cap int) unsafe.Pointer
 
-// slicecopy is used to copy from a string or slice of pointerless elements into a slice.
-//
-//go:linkname slicecopy runtime.slicecopy
-//go:noescape
-func slicecopy(toPtr unsafe.Pointer, toLen int, fromPtr unsafe.Pointer, fromLen int, width uintptr)
-
 //go:linkname typedslicecopy runtime.typedslicecopy
 //go:noescape
 func typedslicecopy(typ *abi.Type, dstPtr unsafe.Pointer, dstLen int, srcPtr unsafe.Pointer, srcLen int)
@@ -57,7 +33,7 @@ func typedslicecopy(typ *abi.Type, dstPtr unsafe.Pointer, dstLen int, srcPtr uns
 func SliceClone(src *Slice, elemType *abi.Type) *Slice {
 	newSlice := makeslice(elemType, src.len, src.len)
 	if !elemType.CanPointer() {
-		slicecopy(newSlice, src.len, src.ptr, src.len, elemType.Size)
+		memmove(newSlice, src.ptr, uintptr(src.len)*elemType.Size)
 	} else {
 		typedslicecopy(elemType, newSlice, src.len, src.ptr, src.len)
 	}
@@ -84,7 +60,7 @@ func SliceCloneInto(dst *Slice, src *Slice, elemType *abi.Type) {
 	}
 
 	if !elemType.CanPointer() {
-		slicecopy(dst.ptr, src.len, src.ptr, src.len, elemType.Size)
+		memmove(dst.ptr, src.ptr, uintptr(src.len)*elemType.Size)
 	} else {
 		typedslicecopy(elemType, dst.ptr, src.len, src.ptr, src.len)
 	}
@@ -101,16 +77,6 @@ func SliceUnpack[T any](s []T) (*Slice, *abi.Type) {
 	return (*Slice)(unsafe.Pointer(&s)), (*SliceType)(abi.NoEscape(unsafe.Pointer(eface.Type))).Elem
 }
 
-//go:nosplit
-func SliceHeader[T any](s []T) *Slice {
-	return (*Slice)(abi.NoEscape(unsafe.Pointer(&s)))
-}
-
-//go:nosplit
-func SlicePack[T any](s *Slice) []T {
-	return *(*[]T)(unsafe.Pointer(s))
-}
-
 //go:nosplit
 func SliceCast[ToT any, FromT any, S ~[]FromT](src S) (ret []ToT) {
 	srcHeader, srcType := SliceUnpack(src)
@@ -122,14 +88,3 @@ func SliceCast[ToT any, FromT any, S ~[]FromT](src S) (ret []ToT) {
 	ret = SlicePack[ToT](srcHeader)
 	return
 }
-
-//go:nosplit
-func StringSliceCast[FromT ~string, S ~[]FromT](src S) []string {
-	return *(*[]string)(unsafe.Pointer(&src))
-}
-
-//go:nosplit
-func UnsafeSliceCast[ToT any, FromT any, S ~[]FromT](src S) []ToT {
-	to := SlicePack[ToT](SliceHeader(src))
-	return to
-}
@@ -0,0 +1,46 @@
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+type Slice struct {
+	ptr unsafe.Pointer
+	len int
+	cap int
+}
+
+func (s *Slice) Ptr() unsafe.Pointer {
+	return s.ptr
+}
+
+func (s *Slice) Len() int {
+	return s.len
+}
+
+func (s *Slice) Cap() int {
+	return s.cap
+}
+
+//go:nosplit
+func SliceHeader[T any](s []T) *Slice {
+	return (*Slice)(abi.NoEscape(unsafe.Pointer(&s)))
+}
+
+//go:nosplit
+func SlicePack[T any](s *Slice) []T {
+	return *(*[]T)(unsafe.Pointer(s))
+}
+
+//go:nosplit
+func StringSliceCast[FromT ~string, S ~[]FromT](src S) []string {
+	return *(*[]string)(unsafe.Pointer(&src))
+}
+
+//go:nosplit
+func UnsafeSliceCast[ToT any, FromT any, S ~[]FromT](src S) []ToT {
+	to := SlicePack[ToT](SliceHeader(src))
+	return to
+}
@@ -0,0 +1,53 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+type SliceCloneFunc = func(src *Slice, elemType *abi.Type) *Slice
+
+// sliceValue returns an addressable reflect.Value of the slice with header s.
+func sliceValue(s *Slice, elemType *abi.Type) reflect.Value {
+	return reflect.NewAt(reflect.SliceOf(ABITypeToReflectType(elemType)), unsafe.Pointer(s)).Elem()
+}
+
+func SliceClone(src *Slice, elemType *abi.Type) *Slice {
+	srcV := sliceValue(src, elemType)
+	dst := new(Slice)
+	dstV := sliceValue(dst, elemType)
+	dstV.Set(reflect.MakeSlice(srcV.Type(), src.len, src.len))
+	reflect.Copy(dstV, srcV)
+	return dst
+}
+
+func SliceCloneInto(dst *Slice, src *Slice, elemType *abi.Type) {
+	srcV := sliceValue(src, elemType)
+	dstV := sliceValue(dst, elemType)
+	if dst.cap < src.len {
+		dstV.Grow(src.len - dst.len)
+	}
+	dstV.SetLen(src.len)
+	reflect.Copy(dstV, srcV)
+}
+
+func SliceCloneAs[T any](src *Slice, elemType *abi.Type) []T {
+	return *(*[]T)(unsafe.Pointer(SliceClone(src, elemType)))
+}
+
+func SliceUnpack[T any](s []T) (*Slice, *abi.Type) {
+	return (*Slice)(unsafe.Pointer(&s)), TypeFor[T]()
+}
+
+func SliceCast[ToT any, FromT any, S ~[]FromT](src S) (ret []ToT) {
+	srcType, dstType := reflect.TypeFor[FromT](), reflect.TypeFor[ToT]()
+	if srcType.Kind() != dstType.Kind() || srcType.Size() != dstType.Size() {
+		panic("SliceCast: type mismatch")
+	}
+	ret = *(*[]ToT)(unsafe.Pointer(&src))
+	return
+}
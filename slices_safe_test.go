@@ -0,0 +1,43 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSafeSliceClone(t *testing.T) {
+	src := []string{"a", "b", "c"}
+	s, elemType := SliceUnpack(src)
+
+	clone := SliceCloneAs[string](s, elemType)
+	if !slices.Equal(clone, src) {
+		t.Fatalf("SliceCloneAs = %v, want %v", clone, src)
+	}
+	clone[0] = "x"
+	if src[0] != "a" {
+		t.Error("clone shares storage with original")
+	}
+
+	dst, _ := SliceUnpack(make([]string, 1))
+	SliceCloneInto(dst, s, elemType)
+	if got := SlicePack[string](dst); !slices.Equal(got, src) {
+		t.Errorf("SliceCloneInto = %v, want %v", got, src)
+	}
+}
+
+func TestSafeSliceCast(t *testing.T) {
+	type myInt int
+	got := SliceCast[int]([]myInt{1, 2, 3})
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("SliceCast = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("SliceCast of mismatched types did not panic")
+		}
+	}()
+	SliceCast[string]([]int{1})
+}
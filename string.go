@@ -29,7 +29,16 @@ func StringPack(s *String) string {
 
 //go:nosplit
 func MakeStringCopy(src string) string {
+	if len(src) == 0 {
+		return ""
+	}
 	b := make([]byte, len(src))
 	copy(b, src)
 	return unsafe.String(&b[0], len(b))
 }
+
+// bytesView returns b as a string without copying.
+// The string must not be used after b is modified.
+func bytesView(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
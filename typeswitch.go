@@ -0,0 +1,110 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// efaceValuePtr returns a pointer to the value held by e.
+//
+// Values of direct-iface types are stored in the data word itself, so
+// the result is the address of e.Data rather than the pointer it holds,
+// and e must point to an interface value that outlives the result. Use a
+// local of the caller rather than EfaceOf, whose result points into its
+// own argument once it is not inlined.
+//
+//go:nosplit
+func efaceValuePtr(e *abi.Eface) unsafe.Pointer {
+	if e.Type.IsDirectIface() {
+		return unsafe.Pointer(&e.Data)
+	}
+	return e.Data
+}
+
+// Is reports whether the dynamic type of v is exactly T.
+//
+// For non-interface T it compares type pointers and never allocates.
+// For interface T it reports whether the dynamic type implements T.
+//
+//go:nosplit
+func Is[T any](v any) bool {
+	typ := TypeFor[T]()
+	e := (*abi.Eface)(abi.NoEscape(unsafe.Pointer(&v)))
+	if typ.Kind() == abi.Interface {
+		return e.Type != nil && Implements(e.Type, typ)
+	}
+	return e.Type == typ
+}
+
+// AsUnchecked returns the value held by v as a T without checking its type.
+//
+// The caller must ensure the dynamic type of v is exactly T (e.g. with Is),
+// and T must not be an interface type.
+//
+//go:nosplit
+func AsUnchecked[T any](v any) T {
+	return *(*T)(efaceValuePtr((*abi.Eface)(abi.NoEscape(unsafe.Pointer(&v)))))
+}
+
+// TypeSwitch dispatches on the dynamic type of a value by looking up its
+// *abi.Type in a table, instead of testing each case in order like a
+// type switch statement does.
+//
+// Cases must be registered before the first call to Switch; after that
+// a TypeSwitch is safe for concurrent use.
+type TypeSwitch[R any] struct {
+	cases    map[*abi.Type]func(unsafe.Pointer) R
+	fallback func(any) R
+}
+
+// NewTypeSwitch returns an empty TypeSwitch.
+func NewTypeSwitch[R any]() *TypeSwitch[R] {
+	return &TypeSwitch[R]{
+		cases: make(map[*abi.Type]func(unsafe.Pointer) R),
+	}
+}
+
+// TypeSwitchCase registers fn as the handler for values whose dynamic type is exactly T.
+//
+// T must not be an interface type.
+func TypeSwitchCase[T, R any](s *TypeSwitch[R], fn func(T) R) *TypeSwitch[R] {
+	typ := TypeFor[T]()
+	if typ.Kind() == abi.Interface {
+		panic("gointernals.TypeSwitchCase of interface type " + typ.String())
+	}
+	s.cases[typ] = func(p unsafe.Pointer) R {
+		return fn(*(*T)(p))
+	}
+	return s
+}
+
+// Default registers fn as the handler for values that match no case.
+func (s *TypeSwitch[R]) Default(fn func(any) R) *TypeSwitch[R] {
+	s.fallback = fn
+	return s
+}
+
+// Has reports whether a case is registered for typ.
+func (s *TypeSwitch[R]) Has(typ *abi.Type) bool {
+	_, ok := s.cases[typ]
+	return ok
+}
+
+// Switch calls the handler registered for the dynamic type of v and returns its result.
+//
+// If no case matches, it calls the default handler if any.
+// It reports false if neither a case nor a default handler was called.
+func (s *TypeSwitch[R]) Switch(v any) (R, bool) {
+	e := (*abi.Eface)(abi.NoEscape(unsafe.Pointer(&v)))
+	if fn, ok := s.cases[e.Type]; ok {
+		return fn(efaceValuePtr(e)), true
+	}
+	if s.fallback != nil {
+		return s.fallback(v), true
+	}
+	var zero R
+	return zero, false
+}
@@ -0,0 +1,83 @@
+//go:build !go1.24 || go1.27 || gointernals_safe
+
+package gointernals
+
+import (
+	"github.com/yusing/gointernals/abi"
+)
+
+// Is reports whether the dynamic type of v is exactly T.
+//
+// For interface T it reports whether the dynamic type implements T.
+func Is[T any](v any) bool {
+	_, ok := v.(T)
+	return ok
+}
+
+// AsUnchecked returns the value held by v as a T.
+//
+// The caller must ensure the dynamic type of v is exactly T (e.g. with Is),
+// and T must not be an interface type. In the fallback build this is a
+// checked type assertion.
+func AsUnchecked[T any](v any) T {
+	return v.(T)
+}
+
+// TypeSwitch dispatches on the dynamic type of a value by looking up its
+// *abi.Type in a table, instead of testing each case in order like a
+// type switch statement does.
+//
+// Cases must be registered before the first call to Switch; after that
+// a TypeSwitch is safe for concurrent use.
+type TypeSwitch[R any] struct {
+	cases    map[*abi.Type]func(any) R
+	fallback func(any) R
+}
+
+// NewTypeSwitch returns an empty TypeSwitch.
+func NewTypeSwitch[R any]() *TypeSwitch[R] {
+	return &TypeSwitch[R]{
+		cases: make(map[*abi.Type]func(any) R),
+	}
+}
+
+// TypeSwitchCase registers fn as the handler for values whose dynamic type is exactly T.
+//
+// T must not be an interface type.
+func TypeSwitchCase[T, R any](s *TypeSwitch[R], fn func(T) R) *TypeSwitch[R] {
+	typ := TypeFor[T]()
+	if typ.Kind() == abi.Interface {
+		panic("gointernals.TypeSwitchCase of interface type " + typ.String())
+	}
+	s.cases[typ] = func(v any) R {
+		return fn(v.(T))
+	}
+	return s
+}
+
+// Default registers fn as the handler for values that match no case.
+func (s *TypeSwitch[R]) Default(fn func(any) R) *TypeSwitch[R] {
+	s.fallback = fn
+	return s
+}
+
+// Has reports whether a case is registered for typ.
+func (s *TypeSwitch[R]) Has(typ *abi.Type) bool {
+	_, ok := s.cases[typ]
+	return ok
+}
+
+// Switch calls the handler registered for the dynamic type of v and returns its result.
+//
+// If no case matches, it calls the default handler if any.
+// It reports false if neither a case nor a default handler was called.
+func (s *TypeSwitch[R]) Switch(v any) (R, bool) {
+	if fn, ok := s.cases[EfaceOf(v).Type]; ok {
+		return fn(v), true
+	}
+	if s.fallback != nil {
+		return s.fallback(v), true
+	}
+	var zero R
+	return zero, false
+}
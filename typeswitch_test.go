@@ -0,0 +1,127 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+type typeSwitchStruct struct {
+	A int
+	B string
+}
+
+func TestIs(t *testing.T) {
+	if !Is[int](42) {
+		t.Error("Expected Is[int](42)")
+	}
+	if Is[int64](42) {
+		t.Error("Expected !Is[int64](42)")
+	}
+	if Is[int](nil) {
+		t.Error("Expected !Is[int](nil)")
+	}
+	if !Is[*int](new(int)) {
+		t.Error("Expected Is[*int](new(int))")
+	}
+	if !Is[fmt.Stringer](ifaceStringer{}) {
+		t.Error("Expected Is[fmt.Stringer](ifaceStringer{})")
+	}
+	if Is[fmt.Stringer](42) || Is[fmt.Stringer](nil) {
+		t.Error("Expected int and nil not to be fmt.Stringer")
+	}
+}
+
+func TestAsUnchecked(t *testing.T) {
+	t.Run("indirect", func(t *testing.T) {
+		v := typeSwitchStruct{A: 1, B: "b"}
+		if got := AsUnchecked[typeSwitchStruct](v); got != v {
+			t.Errorf("Expected %v, got %v", v, got)
+		}
+		if got := AsUnchecked[string]("hello"); got != "hello" {
+			t.Errorf("Expected %q, got %q", "hello", got)
+		}
+	})
+
+	t.Run("direct", func(t *testing.T) {
+		p := new(int)
+		if got := AsUnchecked[*int](p); got != p {
+			t.Errorf("Expected %p, got %p", p, got)
+		}
+		m := map[string]int{"a": 1}
+		if got := AsUnchecked[map[string]int](m); got["a"] != 1 {
+			t.Error("Expected map to be loaded from the data word")
+		}
+		ch := make(chan int, 1)
+		if got := AsUnchecked[chan int](ch); got != ch {
+			t.Error("Expected chan to be loaded from the data word")
+		}
+		single := struct{ p *int }{p}
+		if got := AsUnchecked[struct{ p *int }](single); got.p != p {
+			t.Error("Expected single-pointer struct to be loaded from the data word")
+		}
+	})
+}
+
+func TestTypeSwitch(t *testing.T) {
+	s := NewTypeSwitch[string]()
+	TypeSwitchCase(s, func(v int) string { return "int:" + strconv.Itoa(v) })
+	TypeSwitchCase(s, func(v string) string { return "string:" + v })
+	TypeSwitchCase(s, func(v *int) string { return "ptr:" + strconv.Itoa(*v) })
+	TypeSwitchCase(s, func(v typeSwitchStruct) string { return fmt.Sprintf("struct:%d%s", v.A, v.B) })
+
+	n := 7
+	tests := []struct {
+		v    any
+		want string
+		ok   bool
+	}{
+		{42, "int:42", true},
+		{"x", "string:x", true},
+		{&n, "ptr:7", true},
+		{typeSwitchStruct{1, "b"}, "struct:1b", true},
+		{int64(42), "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := s.Switch(tt.v)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Switch(%v) = %q, %v; want %q, %v", tt.v, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if !s.Has(TypeFor[int]()) || s.Has(TypeFor[int64]()) {
+		t.Error("Unexpected Has result")
+	}
+
+	s.Default(func(v any) string { return fmt.Sprintf("default:%v", v) })
+	if got, ok := s.Switch(int64(1)); got != "default:1" || !ok {
+		t.Errorf("Expected default handler, got %q, %v", got, ok)
+	}
+
+	t.Run("interface case", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected panic for interface case")
+			}
+		}()
+		TypeSwitchCase(s, func(v fmt.Stringer) string { return v.String() })
+	})
+}
+
+func BenchmarkTypeSwitch(b *testing.B) {
+	s := NewTypeSwitch[int]()
+	TypeSwitchCase(s, func(v int) int { return v })
+	TypeSwitchCase(s, func(v int8) int { return int(v) })
+	TypeSwitchCase(s, func(v int16) int { return int(v) })
+	TypeSwitchCase(s, func(v int32) int { return int(v) })
+	TypeSwitchCase(s, func(v int64) int { return int(v) })
+	TypeSwitchCase(s, func(v string) int { return len(v) })
+	var v any = "hello"
+	b.ReportAllocs()
+	for b.Loop() {
+		s.Switch(v)
+	}
+}
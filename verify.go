@@ -0,0 +1,225 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+// verifier collects the mismatches found by Verify.
+type verifier struct {
+	errs []error
+}
+
+func (v *verifier) check(ok bool, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("gointernals.Verify: "+format, args...))
+	}
+}
+
+// Verify cross-checks the hand-copied runtime mirrors in this package against
+// what reflect and unsafe observe at runtime: sizes, offsets, Kind values,
+// flag bits and the type pointers stored in type descriptors.
+//
+// It returns nil if every check passes, or an error listing every mismatch,
+// one per line. Services should call it at startup and refuse to run on
+// error, since a mismatched mirror silently corrupts memory.
+func Verify() error {
+	var v verifier
+	v.verifyKinds()
+	v.verifyType()
+	v.verifyElemTypes()
+	v.verifyStructType()
+	v.verifyFuncType()
+	v.verifyInterfaceType()
+	v.verifyEface()
+	v.verifyHeaders()
+	v.verifyReflectValue()
+	v.verifyMapType()
+	v.verifyMap()
+	return errors.Join(v.errs...)
+}
+
+type verifyStruct struct {
+	A int8
+	B string `verify:"b"`
+	C *int
+	D [3]uint16
+	e any
+}
+
+func (verifyStruct) String() string { return "" }
+
+var verifySamples = []any{
+	false, 0, int8(0), int16(0), int32(0), int64(0),
+	uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+	float32(0), float64(0), complex64(0), complex128(0),
+	[4]int{}, make(chan int), func(int) string { return "" }, (*fmt.Stringer)(nil),
+	map[string]int{}, new(int), []string{}, "", verifyStruct{}, unsafe.Pointer(nil),
+}
+
+func (v *verifier) verifyKinds() {
+	for k := reflect.Invalid; k <= reflect.UnsafePointer; k++ {
+		v.check(abi.Kind(k).String() == k.String(), "abi.Kind(%d) = %s, reflect.Kind(%d) = %s", k, abi.Kind(k), k, k)
+	}
+}
+
+func (v *verifier) verifyType() {
+	for _, x := range verifySamples {
+		rt := reflect.TypeOf(x)
+		typ := TypeOf(x)
+		v.check(ReflectTypeToABIType(rt) == typ, "reflect.Type of %s does not point to its abi.Type", rt)
+		v.check(typ.Kind() == abi.Kind(rt.Kind()), "abi.Type.Kind of %s = %s, want %s", rt, typ.Kind(), rt.Kind())
+		v.check(typ.Size == rt.Size(), "abi.Type.Size of %s = %d, want %d", rt, typ.Size, rt.Size())
+		v.check(int(typ.Align) == rt.Align(), "abi.Type.Align of %s = %d, want %d", rt, typ.Align, rt.Align())
+		v.check(int(typ.FieldAlign) == rt.FieldAlign(), "abi.Type.FieldAlign of %s = %d, want %d", rt, typ.FieldAlign, rt.FieldAlign())
+		v.check(typ.PtrBytes <= typ.Size, "abi.Type.PtrBytes of %s = %d, exceeds size %d", rt, typ.PtrBytes, typ.Size)
+		v.check((typ.Equal != nil) == rt.Comparable(), "abi.Type.Equal of %s is set = %v, want %v", rt, typ.Equal != nil, rt.Comparable())
+		v.check(typ.String() == rt.String(), "abi.Type.String of %s = %q", rt, typ.String())
+		v.check(typ.HasName() == (rt.Name() != ""), "abi.Type.HasName of %s = %v", rt, typ.HasName())
+		v.check(typ.PkgPath() == rt.PkgPath(), "abi.Type.PkgPath of %s = %q, want %q", rt, typ.PkgPath(), rt.PkgPath())
+
+		direct := rt.Kind() == reflect.Pointer || rt.Kind() == reflect.UnsafePointer ||
+			rt.Kind() == reflect.Map || rt.Kind() == reflect.Chan || rt.Kind() == reflect.Func
+		v.check(typ.IsDirectIface() == direct, "abi.Type.IsDirectIface of %s = %v, want %v", rt, typ.IsDirectIface(), direct)
+		v.check(typ.IfaceIndir() == direct, "abi.Type.IfaceIndir of %s = %v, want %v", rt, typ.IfaceIndir(), direct)
+	}
+
+	typ := TypeFor[verifyStruct]()
+	v.check(typ.PtrTo() == TypeFor[*verifyStruct](), "abi.Type.PtrTo of %s does not resolve to its pointer type", typ)
+
+	var s verifyStruct
+	want := []uintptr{unsafe.Offsetof(s.B), unsafe.Offsetof(s.C), unsafe.Offsetof(s.e) + abi.PtrSize}
+	i := 0
+	for off := range typ.PointerOffsets() {
+		v.check(i < len(want) && off == want[i], "abi.Type.PointerOffsets of %s: offset %d = %d", typ, i, off)
+		i++
+	}
+	v.check(i == len(want), "abi.Type.PointerOffsets of %s yielded %d offsets, want %d", typ, i, len(want))
+}
+
+func (v *verifier) verifyElemTypes() {
+	intType := TypeFor[int]()
+
+	pt := (*PointerType)(unsafe.Pointer(TypeFor[*int]()))
+	v.check(pt.Elem == intType, "PointerType.Elem of *int does not point to int")
+
+	st := (*SliceType)(unsafe.Pointer(TypeFor[[]int]()))
+	v.check(st.Elem == intType, "SliceType.Elem of []int does not point to int")
+
+	at := TypeFor[[4]int]().ArrayType()
+	v.check(at.Elem == intType, "abi.ArrayType.Elem of [4]int does not point to int")
+	v.check(at.Slice == TypeFor[[]int](), "abi.ArrayType.Slice of [4]int does not point to []int")
+	v.check(at.Len == 4, "abi.ArrayType.Len of [4]int = %d, want 4", at.Len)
+
+	ct := TypeFor[<-chan int]().ChanType()
+	v.check(ct.Elem == intType, "abi.ChanType.Elem of <-chan int does not point to int")
+	v.check(int(ct.Dir) == int(reflect.RecvDir), "abi.ChanType.Dir of <-chan int = %d, want %d", ct.Dir, reflect.RecvDir)
+}
+
+func (v *verifier) verifyStructType() {
+	rt := reflect.TypeFor[verifyStruct]()
+	st := TypeFor[verifyStruct]().StructType()
+	v.check(st.NumField() == rt.NumField(), "abi.StructType.NumField of %s = %d, want %d", rt, st.NumField(), rt.NumField())
+	for i, f := range st.AllFields() {
+		if i >= rt.NumField() {
+			break
+		}
+		rf := rt.Field(i)
+		v.check(f.Name.Name() == rf.Name, "abi.StructField.Name of %s.%d = %q, want %q", rt, i, f.Name.Name(), rf.Name)
+		v.check(f.Name.Tag() == string(rf.Tag), "abi.StructField.Tag of %s.%s = %q, want %q", rt, rf.Name, f.Name.Tag(), rf.Tag)
+		v.check(f.Offset == rf.Offset, "abi.StructField.Offset of %s.%s = %d, want %d", rt, rf.Name, f.Offset, rf.Offset)
+		v.check(f.Typ == ReflectTypeToABIType(rf.Type), "abi.StructField.Typ of %s.%s does not point to %s", rt, rf.Name, rf.Type)
+		v.check(f.Name.IsExported() == rf.IsExported(), "abi.StructField exported bit of %s.%s = %v", rt, rf.Name, f.Name.IsExported())
+	}
+
+	m, ok := TypeFor[verifyStruct]().MethodByName("String")
+	rm, _ := rt.MethodByName("String")
+	v.check(ok && m.Tfn == rm.Func.UnsafePointer(), "abi.Method.Tfn of %s.String does not match reflect", rt)
+}
+
+func (v *verifier) verifyFuncType() {
+	rt := reflect.TypeFor[func(int, ...string) (bool, error)]()
+	ft := ReflectTypeToABIType(rt).FuncType()
+	v.check(ft.NumIn() == rt.NumIn() && ft.NumOut() == rt.NumOut(), "abi.FuncType of %s has %d in and %d out", rt, ft.NumIn(), ft.NumOut())
+	v.check(ft.IsVariadic() == rt.IsVariadic(), "abi.FuncType.IsVariadic of %s = %v", rt, ft.IsVariadic())
+	for i, in := range ft.Ins() {
+		v.check(i < rt.NumIn() && in == ReflectTypeToABIType(rt.In(i)), "abi.FuncType.In(%d) of %s does not match reflect", i, rt)
+	}
+	for i, out := range ft.Outs() {
+		v.check(i < rt.NumOut() && out == ReflectTypeToABIType(rt.Out(i)), "abi.FuncType.Out(%d) of %s does not match reflect", i, rt)
+	}
+}
+
+func (v *verifier) verifyInterfaceType() {
+	rt := reflect.TypeFor[fmt.State]()
+	it := ReflectTypeToABIType(rt).InterfaceType()
+	v.check(it.NumMethod() == rt.NumMethod(), "abi.InterfaceType.NumMethod of %s = %d, want %d", rt, it.NumMethod(), rt.NumMethod())
+	for i, m := range it.AllMethods() {
+		v.check(i < rt.NumMethod() && it.NameOff(m.Name).Name() == rt.Method(i).Name, "abi.Imethod.Name of %s.%d does not match reflect", rt, i)
+	}
+
+	var s fmt.Stringer = verifyStruct{}
+	iface := (*abi.Iface)(unsafe.Pointer(&s))
+	tab := GetITab(TypeFor[fmt.Stringer](), TypeFor[verifyStruct](), true)
+	v.check(tab == iface.Tab, "GetITab(fmt.Stringer, %s) does not match the compiler's itab", TypeFor[verifyStruct]())
+	v.check(tab != nil && tab.Type == TypeFor[verifyStruct](), "abi.ITab.Type does not point to the concrete type")
+}
+
+func (v *verifier) verifyEface() {
+	p := new(int)
+	// EfaceOf points into its own frame; read the eface of the local.
+	var x any = p
+	e := (*abi.Eface)(unsafe.Pointer(&x))
+	v.check(e.Type == TypeFor[*int](), "abi.Eface.Type of *int does not point to *int")
+	v.check(e.Data == unsafe.Pointer(p), "abi.Eface.Data of direct-iface *int is not the pointer itself")
+	v.check(AsUnchecked[*int](p) == p, "AsUnchecked of a direct-iface value returned a different pointer")
+	v.check(AsUnchecked[string]("verify") == "verify", "AsUnchecked of an indirect value returned a different value")
+}
+
+func (v *verifier) verifyHeaders() {
+	s := make([]int, 2, 5)
+	// SliceHeader points into its own frame; read the header of the local.
+	hdr := (*Slice)(unsafe.Pointer(&s))
+	v.check(hdr.Ptr() == unsafe.Pointer(unsafe.SliceData(s)), "Slice.ptr does not match unsafe.SliceData")
+	v.check(hdr.Len() == len(s), "Slice.len = %d, want %d", hdr.Len(), len(s))
+	v.check(hdr.Cap() == cap(s), "Slice.cap = %d, want %d", hdr.Cap(), cap(s))
+
+	str := "verify"
+	shdr := StringUnpack(str)
+	v.check(shdr.Ptr() == unsafe.Pointer(unsafe.StringData(str)), "String.ptr does not match unsafe.StringData")
+	v.check(shdr.Len() == len(str), "String.len = %d, want %d", shdr.Len(), len(str))
+}
+
+// reflect.Value flag bits, from reflect/value.go.
+const (
+	reflectFlagKindMask = uintptr(1<<5 - 1)
+	reflectFlagAddr     = uintptr(1 << 8)
+)
+
+func (v *verifier) verifyReflectValue() {
+	v.check(unsafe.Sizeof(reflect.Value{}) == 3*unsafe.Sizeof(uintptr(0)), "reflect.Value size = %d, want 3 words", unsafe.Sizeof(reflect.Value{}))
+
+	x := 42
+	direct := reflect.ValueOf(&x)
+	words := (*[3]uintptr)(unsafe.Pointer(&direct))
+	v.check(ReflectValueType(direct) == TypeFor[*int](), "reflect.Value.typ of *int does not point to *int")
+	v.check(words[1] == uintptr(unsafe.Pointer(&x)), "reflect.Value.ptr of direct *int is not the pointer itself")
+	v.check(words[2]&reflectFlagIndir == 0, "reflect.Value.flag of direct *int has flagIndir set")
+	v.check(words[2]&reflectFlagKindMask == uintptr(reflect.Pointer), "reflect.Value.flag kind of *int = %d, want %d", words[2]&reflectFlagKindMask, reflect.Pointer)
+	v.check(reflectValueDataPtr(&direct) == unsafe.Pointer(&words[1]), "reflectValueDataPtr of direct *int is not the address of ptr")
+
+	indirect := direct.Elem()
+	words = (*[3]uintptr)(unsafe.Pointer(&indirect))
+	v.check(ReflectValueType(indirect) == TypeFor[int](), "reflect.Value.typ of int does not point to int")
+	v.check(ReflectValueData(indirect) == unsafe.Pointer(&x), "reflect.Value.ptr of addressable int does not point to it")
+	v.check(words[2]&reflectFlagIndir != 0, "reflect.Value.flag of addressable int does not have flagIndir set")
+	v.check(words[2]&reflectFlagAddr != 0, "reflect.Value.flag of addressable int does not have flagAddr set")
+	v.check(words[2]&reflectFlagKindMask == uintptr(reflect.Int), "reflect.Value.flag kind of int = %d, want %d", words[2]&reflectFlagKindMask, reflect.Int)
+	v.check(reflectValueDataPtr(&indirect) == unsafe.Pointer(&x), "reflectValueDataPtr of addressable int does not point to it")
+}
@@ -0,0 +1,59 @@
+//go:build go1.24 && !go1.26 && !goexperiment.swissmap && !gointernals_safe
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+func (v *verifier) verifyMapType() {
+	type bigKey [MapMaxKeyBytes + 1]byte
+
+	check := func(rt reflect.Type) {
+		mt := (*MapType)(unsafe.Pointer(ReflectTypeToABIType(rt)))
+		v.check(mt.Key == ReflectTypeToABIType(rt.Key()), "MapType.Key of %s does not point to %s", rt, rt.Key())
+		v.check(mt.Elem == ReflectTypeToABIType(rt.Elem()), "MapType.Elem of %s does not point to %s", rt, rt.Elem())
+		v.check(MapElemType(mt) == mt.Elem, "MapElemType of %s does not match MapType.Elem", rt)
+		v.check(mt.Hasher != nil, "MapType.Hasher of %s is nil", rt)
+
+		keySize, elemSize := mt.Key.Size, mt.Elem.Size
+		v.check(mt.IndirectKey() == (keySize > MapMaxKeyBytes), "MapType.IndirectKey of %s = %v", rt, mt.IndirectKey())
+		v.check(mt.IndirectElem() == (elemSize > MapMaxElemBytes), "MapType.IndirectElem of %s = %v", rt, mt.IndirectElem())
+		if mt.IndirectKey() {
+			keySize = abi.PtrSize
+		}
+		if mt.IndirectElem() {
+			elemSize = abi.PtrSize
+		}
+		v.check(uintptr(mt.KeySize) == keySize && uintptr(mt.ValueSize) == elemSize,
+			"MapType.KeySize/ValueSize of %s = %d/%d, want %d/%d", rt, mt.KeySize, mt.ValueSize, keySize, elemSize)
+		v.check(mt.Bucket != nil && uintptr(mt.BucketSize) == mt.Bucket.Size, "MapType.BucketSize of %s does not match its Bucket type", rt)
+		// A bucket holds the tophash array, the keys, the elems and an overflow pointer.
+		v.check(uintptr(mt.BucketSize) >= MapBucketCount*(1+keySize+elemSize)+abi.PtrSize,
+			"MapType.BucketSize of %s = %d is too small", rt, mt.BucketSize)
+	}
+	check(reflect.TypeFor[map[string]int]())
+	check(reflect.TypeFor[map[int8]verifyStruct]())
+	check(reflect.TypeFor[map[bigKey][MapMaxElemBytes + 1]byte]())
+}
+
+func (v *verifier) verifyMap() {
+	small := map[string]int{"a": 1, "b": 2, "c": 3}
+	m, mType := MapUnpack(small)
+	v.check(m.Count == len(small), "Hmap.Count of small map = %d, want %d", m.Count, len(small))
+	v.check(m.B == 0 && m.Buckets != nil, "small Hmap does not have a single bucket (B = %d)", m.B)
+	got, ok := StrMapTryGetAs[string, int](m, mType, "b")
+	v.check(ok && got == 2, "StrMapTryGet of existing key = %d, %v, want 2, true", got, ok)
+
+	large := make(map[int]int)
+	for i := range 4 * MapBucketCount {
+		large[i] = i
+	}
+	m, _ = MapUnpack(large)
+	v.check(m.Count == len(large), "Hmap.Count of large map = %d, want %d", m.Count, len(large))
+	// The load factor is 6.5 entries per bucket.
+	v.check(m.B > 0 && 13<<(m.B-1) >= len(large), "Hmap.B = %d is too small for %d entries", m.B, len(large))
+}
@@ -0,0 +1,62 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe && (go1.26 || goexperiment.swissmap)
+
+package gointernals
+
+import (
+	"reflect"
+	"unsafe"
+
+	"github.com/yusing/gointernals/abi"
+)
+
+func (v *verifier) verifyMapType() {
+	type bigKey [MapMaxKeyBytes + 1]byte
+
+	check := func(rt reflect.Type) {
+		mt := (*MapType)(unsafe.Pointer(ReflectTypeToABIType(rt)))
+		v.check(mt.Key == ReflectTypeToABIType(rt.Key()), "MapType.Key of %s does not point to %s", rt, rt.Key())
+		v.check(mt.Elem == ReflectTypeToABIType(rt.Elem()), "MapType.Elem of %s does not point to %s", rt, rt.Elem())
+		v.check(MapElemType(mt) == mt.Elem, "MapElemType of %s does not match MapType.Elem", rt)
+		v.check(mt.Hasher != nil, "MapType.Hasher of %s is nil", rt)
+
+		keySize, elemSize := mt.Key.Size, mt.Elem.Size
+		v.check(mt.IndirectKey() == (keySize > MapMaxKeyBytes), "MapType.IndirectKey of %s = %v", rt, mt.IndirectKey())
+		v.check(mt.IndirectElem() == (elemSize > MapMaxElemBytes), "MapType.IndirectElem of %s = %v", rt, mt.IndirectElem())
+		if mt.IndirectKey() {
+			keySize = abi.PtrSize
+		}
+		if mt.IndirectElem() {
+			elemSize = abi.PtrSize
+		}
+		v.check(mt.ElemOff >= keySize && mt.SlotSize >= mt.ElemOff+elemSize, "MapType.ElemOff/SlotSize of %s = %d/%d", rt, mt.ElemOff, mt.SlotSize)
+		v.check(mt.Group != nil && mt.GroupSize == mt.Group.Size, "MapType.GroupSize of %s does not match its Group type", rt)
+		v.check(mt.GroupSize == 8+MapGroupSlots*mt.SlotSize, "MapType.GroupSize of %s = %d, want %d", rt, mt.GroupSize, 8+MapGroupSlots*mt.SlotSize)
+	}
+	check(reflect.TypeFor[map[string]int]())
+	check(reflect.TypeFor[map[int8]verifyStruct]())
+	check(reflect.TypeFor[map[bigKey][MapMaxElemBytes + 1]byte]())
+}
+
+func (v *verifier) verifyMap() {
+	small := map[string]int{"a": 1, "b": 2, "c": 3}
+	m, mType := MapUnpack(small)
+	v.check(m.used == uint64(len(small)), "Map.used of small map = %d, want %d", m.used, len(small))
+	v.check(m.dirLen == 0 && m.dirPtr != nil, "small Map is not a single group (dirLen = %d)", m.dirLen)
+	got, ok := StrMapTryGetAs[string, int](m, mType, "b")
+	v.check(ok && got == 2, "StrMapTryGet of existing key = %d, %v, want 2, true", got, ok)
+
+	large := make(map[int]int)
+	for i := range 4 * MapGroupSlots {
+		large[i] = i
+	}
+	m, _ = MapUnpack(large)
+	v.check(m.used == uint64(len(large)), "Map.used of large map = %d, want %d", m.used, len(large))
+	v.check(m.dirLen > 0 && m.dirLen == 1<<m.globalDepth, "Map.dirLen = %d does not match globalDepth %d", m.dirLen, m.globalDepth)
+	if m.dirLen > 0 {
+		t := *(**MapTable)(m.dirPtr)
+		v.check(int(t.used) <= len(large) && t.capacity >= MapGroupSlots && t.capacity&(t.capacity-1) == 0,
+			"MapTable used/capacity = %d/%d", t.used, t.capacity)
+		v.check(uint64(t.capacity) == (t.groups.lengthMask+1)*MapGroupSlots,
+			"MapTable capacity = %d does not match %d groups", t.capacity, t.groups.lengthMask+1)
+	}
+}
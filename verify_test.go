@@ -0,0 +1,28 @@
+//go:build go1.24 && !go1.27 && !gointernals_safe
+
+package gointernals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	if err := Verify(); err != nil {
+		t.Fatalf("Verify() failed:\n%v", err)
+	}
+}
+
+func TestVerifierReport(t *testing.T) {
+	var v verifier
+	v.check(true, "never reported")
+	v.check(false, "first %d", 1)
+	v.check(false, "second %s", "2")
+	if len(v.errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %d", len(v.errs))
+	}
+	report := v.errs[0].Error() + "\n" + v.errs[1].Error()
+	if !strings.Contains(report, "gointernals.Verify: first 1") || !strings.Contains(report, "gointernals.Verify: second 2") {
+		t.Errorf("Unexpected report %q", report)
+	}
+}